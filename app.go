@@ -0,0 +1,246 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package nano
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/lonng/nano/cluster"
+	"github.com/lonng/nano/component"
+	"github.com/lonng/nano/internal/log"
+	"github.com/lonng/nano/scheduler"
+)
+
+// App represents a nano application, which runs a node with its own options and
+// lifecycle, so that multiple applications, e.g. a master, a gate and backends,
+// can run in a process.
+type App struct {
+	name    string          // current application name
+	addr    string          // service address
+	opt     cluster.Options // options of the node
+	startAt time.Time       // startup time
+
+	mu       sync.Mutex
+	starting bool          // whether the application is starting
+	node     *cluster.Node // node of the running application
+	ready    chan struct{} // closed once the application started
+	die      chan struct{} // closed once the application begins to stop
+	done     chan struct{} // closed once the application stopped
+}
+
+// New returns a new application listening on the TCP network address addr
+func New(addr string, opts ...Option) *App {
+	opt := cluster.Options{
+		Components: &component.Components{},
+	}
+	for _, option := range opts {
+		option(&opt)
+	}
+
+	// Use listen address as client address in non-cluster mode
+	if !opt.IsMaster && opt.AdvertiseAddr == "" && len(opt.AdvertiseAddrs) == 0 && opt.Discovery == nil && opt.ClientAddr == "" {
+		log.Println("The current server running in singleton mode")
+		opt.ClientAddr = addr
+	}
+
+	// Set the retry interval to 3 secondes if doesn't set by user
+	if opt.RetryInterval == 0 {
+		opt.RetryInterval = time.Second * 3
+	}
+	if opt.Scheduler == nil {
+		opt.Scheduler = scheduler.Default()
+	}
+
+	return &App{
+		name:  strings.TrimLeft(filepath.Base(os.Args[0]), "/"),
+		addr:  addr,
+		opt:   opt,
+		ready: make(chan struct{}),
+	}
+}
+
+// Node returns the node of the application, or nil if it is not running
+func (a *App) Node() *cluster.Node {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.node
+}
+
+// Ready returns a channel which is closed once the application started, a new
+// one is returned after the application stopped.
+func (a *App) Ready() <-chan struct{} {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.ready
+}
+
+// ClientAddr returns the address the clients connect to, the port is the one
+// bound if the application is running, e.g. the port chosen for port 0.
+func (a *App) ClientAddr() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.node != nil {
+		return a.node.ClientAddr
+	}
+	return a.opt.ClientAddr
+}
+
+// ServiceAddr returns the address of the cluster service, which is listened in
+// cluster mode only, the port is the one bound if the application is running.
+func (a *App) ServiceAddr() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.node != nil {
+		return a.node.ServiceAddr
+	}
+	return a.addr
+}
+
+// Start starts the application and returns once the listeners are bound and
+// the node registered to the cluster, or ctx is done.
+func (a *App) Start(ctx context.Context) error {
+	_, err := a.start(ctx)
+	return err
+}
+
+// start starts the application and returns the channel closed once it begins
+// to stop
+func (a *App) start(ctx context.Context) (chan struct{}, error) {
+	a.mu.Lock()
+	if a.starting || a.node != nil {
+		a.mu.Unlock()
+		return nil, ErrAppRunning
+	}
+	a.starting = true
+	a.mu.Unlock()
+
+	node := &cluster.Node{
+		Options:     a.opt,
+		ServiceAddr: a.addr,
+	}
+	if err := node.StartupContext(ctx); err != nil {
+		a.mu.Lock()
+		a.starting = false
+		a.mu.Unlock()
+		return nil, err
+	}
+	node.Scheduler.Acquire()
+
+	if node.ClientAddr != "" {
+		log.Println(fmt.Sprintf("Startup *Nano gate server* %s, client address: %v, service address: %s",
+			a.name, node.ClientAddr, node.ServiceAddr))
+	} else {
+		log.Println(fmt.Sprintf("Startup *Nano backend server* %s, service address %s",
+			a.name, node.ServiceAddr))
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.startAt = time.Now()
+	a.starting = false
+	a.node = node
+	a.die = make(chan struct{})
+	a.done = make(chan struct{})
+	close(a.ready)
+	return a.die, nil
+}
+
+// Stop drains the node if DrainTimeout specified and shuts it down, it returns
+// once the application stopped, or ctx is done and the application keeps
+// stopping in background. The draining is bounded by ctx as well.
+func (a *App) Stop(ctx context.Context) error {
+	a.mu.Lock()
+	node, done := a.node, a.done
+	if node == nil {
+		a.mu.Unlock()
+		return nil
+	}
+	select {
+	case <-a.die:
+		// Stopping by the other caller
+	default:
+		close(a.die)
+		go a.stop(ctx, node)
+	}
+	a.mu.Unlock()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (a *App) stop(ctx context.Context, node *cluster.Node) {
+	log.Println("Nano server is stopping...")
+
+	if node.DrainTimeout > 0 {
+		ctx, cancel := context.WithTimeout(ctx, node.DrainTimeout)
+		if err := node.Drain(ctx); err != nil {
+			log.Println("Drain current node failed", err)
+		}
+		cancel()
+	}
+
+	node.Shutdown()
+	node.Scheduler.Release()
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.node = nil
+	a.ready = make(chan struct{})
+	close(a.done)
+}
+
+// Listen starts the application and blocks until Shutdown called or the
+// process signaled, then stops the application.
+func (a *App) Listen() error {
+	die, err := a.start(context.Background())
+	if err != nil {
+		return err
+	}
+
+	sg := make(chan os.Signal, 1)
+	signal.Notify(sg, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGKILL, syscall.SIGTERM)
+	defer signal.Stop(sg)
+
+	select {
+	case <-die:
+		log.Println("The app will shutdown in a few seconds")
+	case s := <-sg:
+		log.Println("Nano server got signal", s)
+	}
+	return a.Stop(context.Background())
+}
+
+// Shutdown send a signal to let the application stop itself, see Stop.
+func (a *App) Shutdown() {
+	go a.Stop(context.Background())
+}
@@ -0,0 +1,181 @@
+package nano
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/lonng/nano/benchmark/testdata"
+	"github.com/lonng/nano/client"
+	"github.com/lonng/nano/component"
+	"github.com/lonng/nano/scheduler"
+	"github.com/lonng/nano/session"
+)
+
+type AppComponent struct{ component.Base }
+
+func (c *AppComponent) Ping(s *session.Session, ping *testdata.Ping) error {
+	return s.Response(&testdata.Pong{Content: ping.Content})
+}
+
+func newTestApp(addr string) *App {
+	comps := &component.Components{}
+	comps.Register(&AppComponent{})
+	return New(addr, WithComponents(comps), WithScheduler(scheduler.NewScheduler("app")))
+}
+
+func isReady(app *App) bool {
+	select {
+	case <-app.Ready():
+		return true
+	default:
+		return false
+	}
+}
+
+func TestAppStartStop(t *testing.T) {
+	app := newTestApp("127.0.0.1:0")
+	if isReady(app) {
+		t.Fatal("app is ready before started")
+	}
+
+	for i := 0; i < 2; i++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+		defer cancel()
+		if err := app.Start(ctx); err != nil {
+			t.Fatalf("start app failed: %v", err)
+		}
+		if !isReady(app) {
+			t.Fatal("app is not ready after started")
+		}
+		if err := app.Start(ctx); err != ErrAppRunning {
+			t.Fatalf("expect %v, got %v", ErrAppRunning, err)
+		}
+
+		addr := app.ClientAddr()
+		if _, port, _ := net.SplitHostPort(addr); port == "0" || port == "" {
+			t.Fatalf("unexpected client address %s", addr)
+		}
+		cli, err := client.Dial(ctx, addr)
+		if err != nil {
+			t.Fatalf("dial %s failed: %v", addr, err)
+		}
+		pong := &testdata.Pong{}
+		if err := cli.Request(ctx, "AppComponent.Ping", &testdata.Ping{Content: "ping"}, pong); err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+		if pong.Content != "ping" {
+			t.Fatalf("expect pong ping, got %s", pong.Content)
+		}
+		cli.Close()
+
+		if err := app.Stop(ctx); err != nil {
+			t.Fatalf("stop app failed: %v", err)
+		}
+		if isReady(app) || app.Node() != nil {
+			t.Fatal("app is still running after stopped")
+		}
+		if err := app.Stop(ctx); err != nil {
+			t.Fatalf("stop stopped app failed: %v", err)
+		}
+		if _, err := net.DialTimeout("tcp", addr, time.Second); err == nil {
+			t.Fatalf("client address %s is still listened after stopped", addr)
+		}
+	}
+}
+
+func TestAppStartFailed(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer listener.Close()
+
+	app := newTestApp(listener.Addr().String())
+	if err := app.Start(context.Background()); err == nil {
+		t.Fatal("expect the address in use error")
+	}
+	if isReady(app) || app.Node() != nil {
+		t.Fatal("app is running after start failed")
+	}
+}
+
+func TestAppStartCanceled(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Nobody serves the master address
+	listener.Close()
+
+	app := New("127.0.0.1:0", WithAdvertiseAddr(listener.Addr().String()))
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	if err := app.Start(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expect %v, got %v", context.DeadlineExceeded, err)
+	}
+	if isReady(app) || app.Node() != nil {
+		t.Fatal("app is running after start canceled")
+	}
+}
+
+func TestAppListen(t *testing.T) {
+	app := newTestApp("127.0.0.1:0")
+	errc := make(chan error, 1)
+	go func() { errc <- app.Listen() }()
+
+	select {
+	case <-app.Ready():
+	case <-time.After(3 * time.Second):
+		t.Fatal("app is not ready")
+	}
+	app.Shutdown()
+
+	select {
+	case err := <-errc:
+		if err != nil {
+			t.Fatalf("listen failed: %v", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("app is not stopped")
+	}
+}
+
+func TestAppCluster(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	master := New("127.0.0.1:0", WithMaster())
+	if err := master.Start(ctx); err != nil {
+		t.Fatalf("start master failed: %v", err)
+	}
+	defer master.Stop(ctx)
+
+	comps := &component.Components{}
+	comps.Register(&AppComponent{})
+	backend := New("127.0.0.1:0", WithAdvertiseAddr(master.ServiceAddr()), WithComponents(comps))
+	if err := backend.Start(ctx); err != nil {
+		t.Fatalf("start backend failed: %v", err)
+	}
+	defer backend.Stop(ctx)
+
+	gate := New("127.0.0.1:0", WithAdvertiseAddr(master.ServiceAddr()), WithClientAddr("127.0.0.1:0"))
+	if err := gate.Start(ctx); err != nil {
+		t.Fatalf("start gate failed: %v", err)
+	}
+	defer gate.Stop(ctx)
+
+	cli, err := client.Dial(ctx, gate.ClientAddr())
+	if err != nil {
+		t.Fatalf("dial gate failed: %v", err)
+	}
+	defer cli.Close()
+	pong := &testdata.Pong{}
+	if err := cli.Request(ctx, "AppComponent.Ping", &testdata.Ping{Content: "cluster"}, pong); err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if pong.Content != "cluster" {
+		t.Fatalf("expect pong cluster, got %s", pong.Content)
+	}
+}
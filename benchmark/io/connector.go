@@ -56,6 +56,8 @@ type (
 	Callback func(data interface{})
 
 	// Connector is a tiny Nano client
+	//
+	// Deprecated: use the client package instead.
 	Connector struct {
 		conn   net.Conn       // low-level connection
 		codec  *codec.Decoder // decoder
@@ -292,7 +294,16 @@ func (c *Connector) processMessage(msg *message.Message) {
 			return
 		}
 
-		cb(msg.Data)
+		// The callback receives a *message.Error if the request failed
+		if msg.Err {
+			if e, err := message.DecodeError(msg.Data); err != nil {
+				log.Println(err.Error())
+			} else {
+				cb(e)
+			}
+		} else {
+			cb(msg.Data)
+		}
 		c.setResponseHandler(msg.ID, nil)
 	}
 }
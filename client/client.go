@@ -0,0 +1,590 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/lonng/nano/internal/codec"
+	"github.com/lonng/nano/internal/log"
+	"github.com/lonng/nano/internal/message"
+	"github.com/lonng/nano/internal/packet"
+	"github.com/lonng/nano/session"
+)
+
+// Version is the client version reported in the handshake
+const Version = "0.1.0"
+
+var (
+	hbd []byte // heartbeat packet data
+	had []byte // handshake ack data
+)
+
+func init() {
+	var err error
+	hbd, err = codec.Encode(packet.Heartbeat, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	had, err = codec.Encode(packet.HandshakeAck, nil)
+	if err != nil {
+		panic(err)
+	}
+}
+
+type (
+	// KickReason is the reason carried in the kick packet
+	KickReason = session.KickReason
+
+	// PushHandler handles the data pushed by the server, it is called in the
+	// read loop of the connection and should not block
+	PushHandler func(data []byte)
+
+	// Client is a nano client which communicates with the server over a
+	// low-level connection established by the dialer
+	Client struct {
+		addr string
+		opts options
+
+		mu        sync.Mutex
+		conn      net.Conn                 // current low-level connection
+		dict      *message.Dictionary      // route dictionary received in the handshake
+		heartbeat time.Duration            // heartbeat interval received in the handshake
+		token     string                   // resume token of the session
+		acked     uint64                   // number of data packets received in the session
+		mid       uint64                   // last request id
+		pending   map[uint64]chan response // pending requests
+		handlers  map[string]PushHandler   // push handlers indexed by route
+		err       error                    // reason the client closed
+
+		wmu    sync.Mutex    // serialize writes to the connection
+		lastAt int64         // last time a packet received, unix nano
+		die    chan struct{} // client closed
+	}
+
+	response struct {
+		msg *message.Message
+		err error
+	}
+
+	handshakeRequest struct {
+		Sys struct {
+			Type    string  `json:"type"`
+			Version string  `json:"version"`
+			Resume  string  `json:"resume,omitempty"`
+			Acked   *uint64 `json:"acked,omitempty"`
+		} `json:"sys"`
+		User interface{} `json:"user,omitempty"`
+	}
+
+	handshakeResponse struct {
+		Code int `json:"code"`
+		Sys  struct {
+			Heartbeat float64           `json:"heartbeat"`
+			Dict      map[string]uint16 `json:"dict"`
+			Resume    string            `json:"resume"`
+			Resumed   bool              `json:"resumed"`
+		} `json:"sys"`
+	}
+)
+
+// Dial connects to the server at addr and finishes the handshake, ctx only
+// bounds the dialing and the handshake
+func Dial(ctx context.Context, addr string, opts ...Option) (*Client, error) {
+	c := &Client{
+		addr:     addr,
+		opts:     defaultOptions(),
+		dict:     message.NewDictionary(nil),
+		pending:  map[uint64]chan response{},
+		handlers: map[string]PushHandler{},
+		die:      make(chan struct{}),
+	}
+	for i := range opts {
+		opt := opts[i]
+		opt(&c.opts)
+	}
+
+	conn, decoder, packets, err := c.connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	c.serve(conn, decoder, packets)
+	return c, nil
+}
+
+// Request sends a request to the handler of route and waits for the response,
+// which will be unmarshaled into reply. A *Error is returned if the server
+// responds an error.
+func (c *Client) Request(ctx context.Context, route string, v, reply interface{}) error {
+	data, err := c.serialize(v)
+	if err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return c.err
+	}
+	c.mid++
+	mid := c.mid
+	ch := make(chan response, 1)
+	c.pending[mid] = ch
+	c.mu.Unlock()
+
+	msg := &message.Message{
+		Type:  message.Request,
+		ID:    mid,
+		Route: route,
+		Data:  data,
+	}
+	if err := c.send(msg); err != nil {
+		c.removePending(mid)
+		return err
+	}
+
+	select {
+	case resp := <-ch:
+		if resp.err != nil {
+			return resp.err
+		}
+		if resp.msg.Err {
+			e, err := message.DecodeError(resp.msg.Data)
+			if err != nil {
+				return err
+			}
+			return e
+		}
+		return c.deserialize(resp.msg.Data, reply)
+	case <-ctx.Done():
+		c.removePending(mid)
+		return ctx.Err()
+	}
+}
+
+// Notify sends a notification to the handler of route
+func (c *Client) Notify(route string, v interface{}) error {
+	data, err := c.serialize(v)
+	if err != nil {
+		return err
+	}
+	return c.send(&message.Message{
+		Type:  message.Notify,
+		Route: route,
+		Data:  data,
+	})
+}
+
+// On subscribes the data pushed by the server on route, the handler replaces
+// the previous one of the route
+func (c *Client) On(route string, handler PushHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.handlers[route] = handler
+}
+
+// Off unsubscribes the data pushed by the server on route
+func (c *Client) Off(route string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.handlers, route)
+}
+
+// Unmarshal unmarshals the pushed data with the serializer of the client
+func (c *Client) Unmarshal(data []byte, v interface{}) error {
+	return c.deserialize(data, v)
+}
+
+// Close closes the connection and fails the pending requests
+func (c *Client) Close() error {
+	c.close(ErrClosed)
+	return nil
+}
+
+// Done returns a channel which is closed when the client closed
+func (c *Client) Done() <-chan struct{} {
+	return c.die
+}
+
+// Err returns the reason the client closed, which is ErrClosed if closed by
+// Close, a *KickError if kicked by server, or the error of the connection
+func (c *Client) Err() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.err
+}
+
+// connect dials the server and finishes the handshake, which resumes the
+// session if a resume token has been issued. It returns the packets received
+// after the handshake response, which should be processed before others.
+func (c *Client) connect(ctx context.Context) (net.Conn, *codec.Decoder, []*packet.Packet, error) {
+	conn, err := c.opts.dialer(ctx, c.addr)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Abort the handshake once ctx done
+	done, exited := make(chan struct{}), make(chan struct{})
+	go func() {
+		defer close(exited)
+		select {
+		case <-ctx.Done():
+			conn.SetDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	req := &handshakeRequest{User: c.opts.userData}
+	req.Sys.Type = c.opts.clientType
+	req.Sys.Version = c.opts.version
+	c.mu.Lock()
+	if c.token != "" {
+		acked := c.acked
+		req.Sys.Resume = c.token
+		req.Sys.Acked = &acked
+	}
+	c.mu.Unlock()
+
+	decoder := codec.NewDecoder()
+	resp, packets, err := c.handshake(conn, decoder, req)
+	close(done)
+	<-exited
+	if ctx.Err() != nil {
+		err = ctx.Err()
+	} else if err == nil {
+		err = conn.SetDeadline(time.Time{})
+	}
+	if err != nil {
+		conn.Close()
+		return nil, nil, nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		conn.Close()
+		return nil, nil, nil, c.err
+	}
+	if !resp.Sys.Resumed {
+		c.acked = 0
+		for mid, ch := range c.pending {
+			ch <- response{err: ErrSessionLost}
+			delete(c.pending, mid)
+		}
+	}
+	c.conn = conn
+	c.token = resp.Sys.Resume
+	c.heartbeat = time.Duration(resp.Sys.Heartbeat * float64(time.Second))
+	c.dict = message.NewDictionary(resp.Sys.Dict)
+	atomic.StoreInt64(&c.lastAt, time.Now().UnixNano())
+	return conn, decoder, packets, nil
+}
+
+func (c *Client) handshake(conn net.Conn, decoder *codec.Decoder, req *handshakeRequest) (*handshakeResponse, []*packet.Packet, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	p, err := codec.Encode(packet.Handshake, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if _, err := conn.Write(p); err != nil {
+		return nil, nil, err
+	}
+
+	var packets []*packet.Packet
+	buf := make([]byte, 2048)
+	for len(packets) == 0 {
+		n, err := conn.Read(buf)
+		if err != nil {
+			return nil, nil, err
+		}
+		packets, err = decoder.Decode(buf[:n])
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	if packets[0].Type != packet.Handshake {
+		return nil, nil, ErrHandshakeFailure
+	}
+	resp := &handshakeResponse{}
+	if err := json.Unmarshal(packets[0].Data, resp); err != nil {
+		return nil, nil, err
+	}
+	if resp.Code != 200 {
+		return nil, nil, fmt.Errorf("%v (code: %d)", ErrHandshakeFailure, resp.Code)
+	}
+	if _, err := conn.Write(had); err != nil {
+		return nil, nil, err
+	}
+	return resp, packets[1:], nil
+}
+
+// serve reads the connection and sends heartbeat until the connection broken
+func (c *Client) serve(conn net.Conn, decoder *codec.Decoder, packets []*packet.Packet) {
+	stop := make(chan struct{})
+	go c.keepalive(conn, stop)
+	go func() {
+		err := c.read(conn, decoder, packets)
+		close(stop)
+		c.disconnected(conn, err)
+	}()
+}
+
+func (c *Client) read(conn net.Conn, decoder *codec.Decoder, packets []*packet.Packet) error {
+	buf := make([]byte, 2048)
+	for {
+		for _, p := range packets {
+			if err := c.processPacket(p); err != nil {
+				return err
+			}
+		}
+
+		n, err := conn.Read(buf)
+		if err != nil {
+			return err
+		}
+		atomic.StoreInt64(&c.lastAt, time.Now().UnixNano())
+		packets, err = decoder.Decode(buf[:n])
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (c *Client) processPacket(p *packet.Packet) error {
+	switch p.Type {
+	case packet.Data:
+		c.mu.Lock()
+		c.acked++
+		dict := c.dict
+		c.mu.Unlock()
+
+		msg, err := dict.Decode(p.Data)
+		if err != nil {
+			return err
+		}
+		c.processMessage(msg)
+
+	case packet.Kick:
+		reason := KickReason{}
+		if len(p.Data) > 0 {
+			if err := json.Unmarshal(p.Data, &reason); err != nil {
+				log.Println(fmt.Sprintf("Decode kick reason failed: %+v", err))
+			}
+		}
+		return &KickError{Reason: reason}
+
+	case packet.Heartbeat:
+		// expected
+	}
+	return nil
+}
+
+func (c *Client) processMessage(msg *message.Message) {
+	switch msg.Type {
+	case message.Response:
+		c.mu.Lock()
+		ch, found := c.pending[msg.ID]
+		delete(c.pending, msg.ID)
+		c.mu.Unlock()
+		if found {
+			ch <- response{msg: msg}
+		}
+
+	case message.Push:
+		c.mu.Lock()
+		handler, found := c.handlers[msg.Route]
+		c.mu.Unlock()
+		if !found {
+			log.Println(fmt.Sprintf("Push handler not found, Route=%s", msg.Route))
+			return
+		}
+		handler(msg.Data)
+	}
+}
+
+// keepalive sends heartbeat to the server and breaks the connection if no
+// packet received in two heartbeat intervals
+func (c *Client) keepalive(conn net.Conn, stop chan struct{}) {
+	c.mu.Lock()
+	heartbeat := c.heartbeat
+	c.mu.Unlock()
+	if heartbeat <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(heartbeat)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if time.Since(time.Unix(0, atomic.LoadInt64(&c.lastAt))) > 2*heartbeat {
+				log.Println(fmt.Sprintf("Heartbeat timeout, Remote=%s", conn.RemoteAddr()))
+				conn.Close()
+				return
+			}
+			if err := c.write(conn, hbd); err != nil {
+				return
+			}
+		case <-stop:
+			return
+		}
+	}
+}
+
+// disconnected closes the client or reconnects to the server after the
+// connection broken
+func (c *Client) disconnected(conn net.Conn, err error) {
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return
+	}
+	c.conn = nil
+	c.mu.Unlock()
+	conn.Close()
+
+	if ke, ok := err.(*KickError); ok {
+		c.close(err)
+		if fn := c.opts.onKick; fn != nil {
+			fn(ke.Reason)
+		}
+		return
+	}
+	if fn := c.opts.onDisconnect; fn != nil {
+		fn(err)
+	}
+	if c.opts.retryInterval <= 0 {
+		c.close(err)
+		return
+	}
+	c.reconnect()
+}
+
+func (c *Client) reconnect() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-c.die:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		select {
+		case <-time.After(c.opts.retryInterval):
+		case <-c.die:
+			return
+		}
+		conn, decoder, packets, err := c.connect(ctx)
+		if err != nil {
+			log.Println(fmt.Sprintf("Reconnect to %s failed: %+v", c.addr, err))
+			continue
+		}
+		c.serve(conn, decoder, packets)
+		return
+	}
+}
+
+func (c *Client) close(err error) {
+	c.mu.Lock()
+	if c.err != nil {
+		c.mu.Unlock()
+		return
+	}
+	c.err = err
+	conn := c.conn
+	c.conn = nil
+	for mid, ch := range c.pending {
+		ch <- response{err: err}
+		delete(c.pending, mid)
+	}
+	close(c.die)
+	c.mu.Unlock()
+
+	if conn != nil {
+		conn.Close()
+	}
+}
+
+func (c *Client) removePending(mid uint64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.pending, mid)
+}
+
+func (c *Client) send(msg *message.Message) error {
+	c.mu.Lock()
+	conn, dict, err := c.conn, c.dict, c.err
+	c.mu.Unlock()
+	if err != nil {
+		return err
+	}
+	if conn == nil {
+		return ErrDisconnected
+	}
+
+	data, err := dict.Encode(msg)
+	if err != nil {
+		return err
+	}
+	p, err := codec.Encode(packet.Data, data)
+	if err != nil {
+		return err
+	}
+	return c.write(conn, p)
+}
+
+func (c *Client) write(conn net.Conn, data []byte) error {
+	c.wmu.Lock()
+	defer c.wmu.Unlock()
+	_, err := conn.Write(data)
+	return err
+}
+
+func (c *Client) serialize(v interface{}) ([]byte, error) {
+	if data, ok := v.([]byte); ok {
+		return data, nil
+	}
+	return c.opts.serializer.Marshal(v)
+}
+
+func (c *Client) deserialize(data []byte, v interface{}) error {
+	switch r := v.(type) {
+	case nil:
+		return nil
+	case *[]byte:
+		*r = data
+		return nil
+	}
+	return c.opts.serializer.Unmarshal(data, v)
+}
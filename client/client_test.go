@@ -0,0 +1,232 @@
+package client_test
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/lonng/nano/benchmark/testdata"
+	"github.com/lonng/nano/client"
+	"github.com/lonng/nano/cluster"
+	"github.com/lonng/nano/component"
+	"github.com/lonng/nano/scheduler"
+	"github.com/lonng/nano/session"
+	. "github.com/pingcap/check"
+)
+
+type clientSuite struct {
+	node   *cluster.Node
+	wsNode *cluster.Node
+}
+
+var _ = Suite(&clientSuite{})
+
+func TestClient(t *testing.T) {
+	TestingT(t)
+}
+
+type TestComponent struct{ component.Base }
+
+func (c *TestComponent) Echo(ctx context.Context, s *session.Session, ping *testdata.Ping) (*testdata.Pong, error) {
+	return &testdata.Pong{Content: ping.Content}, nil
+}
+
+func (c *TestComponent) Handshake(ctx context.Context, s *session.Session, _ []byte) (*testdata.Pong, error) {
+	return &testdata.Pong{Content: s.String("handshake")}, nil
+}
+
+func (c *TestComponent) Fail(s *session.Session, ping *testdata.Ping) error {
+	return &client.Error{Code: 1001, Message: ping.Content}
+}
+
+func (c *TestComponent) Silent(s *session.Session, ping *testdata.Ping) error {
+	return nil
+}
+
+func (c *TestComponent) Push(s *session.Session, ping *testdata.Ping) error {
+	return s.Push("onPush", &testdata.Pong{Content: ping.Content})
+}
+
+func (c *TestComponent) Kick(s *session.Session, ping *testdata.Ping) error {
+	return s.Kick(session.KickReason{Code: 1002, Message: ping.Content})
+}
+
+func (c *TestComponent) Set(s *session.Session, ping *testdata.Ping) error {
+	s.Set("content", ping.Content)
+	return s.Response(&testdata.Pong{Content: "ok"})
+}
+
+func (c *TestComponent) Get(s *session.Session, ping *testdata.Ping) error {
+	return s.Response(&testdata.Pong{Content: s.String("content")})
+}
+
+func (s *clientSuite) SetUpSuite(c *C) {
+	go scheduler.Sched()
+
+	validator := func(s *session.Session, data []byte) error {
+		s.Set("handshake", string(data))
+		return nil
+	}
+	dict := map[string]uint16{
+		"TestComponent.Echo": 1,
+		"onPush":             2,
+	}
+
+	comps := &component.Components{}
+	comps.Register(&TestComponent{})
+	s.node = &cluster.Node{
+		Options: cluster.Options{
+			IsMaster:     true,
+			ClientAddr:   "127.0.0.1:14540",
+			Components:   comps,
+			ResumeWindow: time.Second,
+
+			HandshakeValidator: validator,
+			Dictionary:         dict,
+		},
+		ServiceAddr: "127.0.0.1:4540",
+	}
+	c.Assert(s.node.Startup(), IsNil)
+
+	wsComps := &component.Components{}
+	wsComps.Register(&TestComponent{})
+	s.wsNode = &cluster.Node{
+		Options: cluster.Options{
+			IsMaster:    true,
+			IsWebsocket: true,
+			ClientAddr:  "127.0.0.1:14541",
+			Components:  wsComps,
+
+			HandshakeValidator: validator,
+			Dictionary:         dict,
+		},
+		ServiceAddr: "127.0.0.1:4541",
+	}
+	c.Assert(s.wsNode.Startup(), IsNil)
+	time.Sleep(50 * time.Millisecond)
+}
+
+func (s *clientSuite) TearDownSuite(c *C) {
+	s.node.Shutdown()
+	s.wsNode.Shutdown()
+	scheduler.Close()
+}
+
+func (s *clientSuite) TestRequest(c *C) {
+	cli, err := client.Dial(context.Background(), "127.0.0.1:14540",
+		client.WithHandshakeData(map[string]string{"token": "secret"}))
+	c.Assert(err, IsNil)
+	defer cli.Close()
+
+	// Route compressed by the dictionary in both directions
+	pong := &testdata.Pong{}
+	c.Assert(cli.Request(context.Background(), "TestComponent.Echo", &testdata.Ping{Content: "hello"}, pong), IsNil)
+	c.Assert(pong.Content, Equals, "hello")
+
+	// Handshake user data
+	c.Assert(cli.Request(context.Background(), "TestComponent.Handshake", []byte{}, pong), IsNil)
+	c.Assert(pong.Content, Matches, `.*"user":\{"token":"secret"\}.*`)
+
+	// Structured error
+	err = cli.Request(context.Background(), "TestComponent.Fail", &testdata.Ping{Content: "not enough gold"}, pong)
+	c.Assert(err, DeepEquals, &client.Error{Code: 1001, Message: "not enough gold"})
+
+	// Timeout via context
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = cli.Request(ctx, "TestComponent.Silent", &testdata.Ping{}, pong)
+	c.Assert(err, Equals, context.DeadlineExceeded)
+}
+
+func (s *clientSuite) TestPush(c *C) {
+	cli, err := client.Dial(context.Background(), "127.0.0.1:14540")
+	c.Assert(err, IsNil)
+	defer cli.Close()
+
+	pushed := make(chan string, 1)
+	cli.On("onPush", func(data []byte) {
+		pong := &testdata.Pong{}
+		c.Assert(cli.Unmarshal(data, pong), IsNil)
+		pushed <- pong.Content
+	})
+	c.Assert(cli.Notify("TestComponent.Push", &testdata.Ping{Content: "pushed"}), IsNil)
+	select {
+	case content := <-pushed:
+		c.Assert(content, Equals, "pushed")
+	case <-time.After(time.Second):
+		c.Fatal("push not received")
+	}
+}
+
+func (s *clientSuite) TestKick(c *C) {
+	kicked := make(chan client.KickReason, 1)
+	cli, err := client.Dial(context.Background(), "127.0.0.1:14540",
+		client.WithReconnect(10*time.Millisecond),
+		client.WithKickHandler(func(reason client.KickReason) { kicked <- reason }))
+	c.Assert(err, IsNil)
+	defer cli.Close()
+
+	c.Assert(cli.Notify("TestComponent.Kick", &testdata.Ping{Content: "bye"}), IsNil)
+	select {
+	case reason := <-kicked:
+		c.Assert(reason, DeepEquals, client.KickReason{Code: 1002, Message: "bye"})
+	case <-time.After(time.Second):
+		c.Fatal("kick not received")
+	}
+	<-cli.Done()
+	c.Assert(cli.Err(), DeepEquals, &client.KickError{Reason: client.KickReason{Code: 1002, Message: "bye"}})
+}
+
+func (s *clientSuite) TestResume(c *C) {
+	var mu sync.Mutex
+	var conns []net.Conn
+	dialer := func(ctx context.Context, addr string) (net.Conn, error) {
+		conn, err := client.TCPDialer()(ctx, addr)
+		if err == nil {
+			mu.Lock()
+			conns = append(conns, conn)
+			mu.Unlock()
+		}
+		return conn, err
+	}
+	cli, err := client.Dial(context.Background(), "127.0.0.1:14540",
+		client.WithDialer(dialer),
+		client.WithReconnect(10*time.Millisecond))
+	c.Assert(err, IsNil)
+	defer cli.Close()
+
+	pong := &testdata.Pong{}
+	c.Assert(cli.Request(context.Background(), "TestComponent.Set", &testdata.Ping{Content: "resumed"}, pong), IsNil)
+
+	// Break the connection, the session is resumed after reconnected
+	mu.Lock()
+	conns[0].Close()
+	mu.Unlock()
+	for i := 0; i < 100; i++ {
+		if err = cli.Request(context.Background(), "TestComponent.Get", &testdata.Ping{}, pong); err != client.ErrDisconnected {
+			break
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	c.Assert(err, IsNil)
+	c.Assert(pong.Content, Equals, "resumed")
+	mu.Lock()
+	c.Assert(conns, HasLen, 2)
+	mu.Unlock()
+}
+
+func (s *clientSuite) TestWebSocket(c *C) {
+	cli, err := client.Dial(context.Background(), "127.0.0.1:14541",
+		client.WithDialer(client.WebSocketDialer("/", nil)))
+	c.Assert(err, IsNil)
+	defer cli.Close()
+
+	pong := &testdata.Pong{}
+	c.Assert(cli.Request(context.Background(), "TestComponent.Echo", &testdata.Ping{Content: "websocket"}, pong), IsNil)
+	c.Assert(pong.Content, Equals, "websocket")
+
+	c.Assert(cli.Close(), IsNil)
+	c.Assert(cli.Request(context.Background(), "TestComponent.Echo", &testdata.Ping{}, pong), Equals, client.ErrClosed)
+}
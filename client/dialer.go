@@ -0,0 +1,62 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/url"
+
+	"github.com/gorilla/websocket"
+	"github.com/lonng/nano/internal/wsconn"
+)
+
+// Dialer establishes the low-level connection to the server address
+type Dialer func(ctx context.Context, addr string) (net.Conn, error)
+
+// TCPDialer returns a Dialer which connects to the server over TCP
+func TCPDialer() Dialer {
+	return func(ctx context.Context, addr string) (net.Conn, error) {
+		var d net.Dialer
+		return d.DialContext(ctx, "tcp", addr)
+	}
+}
+
+// WebSocketDialer returns a Dialer which connects to the server over
+// WebSocket on path, the connection is secured if tlsConfig is not nil
+func WebSocketDialer(path string, tlsConfig *tls.Config) Dialer {
+	return func(ctx context.Context, addr string) (net.Conn, error) {
+		u := url.URL{Scheme: "ws", Host: addr, Path: path}
+		if tlsConfig != nil {
+			u.Scheme = "wss"
+		}
+		d := websocket.Dialer{
+			Proxy:           websocket.DefaultDialer.Proxy,
+			TLSClientConfig: tlsConfig,
+		}
+		conn, _, err := d.DialContext(ctx, u.String(), nil)
+		if err != nil {
+			return nil, err
+		}
+		return wsconn.New(conn), nil
+	}
+}
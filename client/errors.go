@@ -0,0 +1,48 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package client
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/lonng/nano/internal/message"
+)
+
+// Errors that could be occurred when communicating with server
+var (
+	ErrClosed           = errors.New("client closed")
+	ErrDisconnected     = errors.New("client disconnected")
+	ErrSessionLost      = errors.New("session lost after reconnected")
+	ErrHandshakeFailure = errors.New("handshake failure")
+)
+
+// Error is the error responded by the server for a failed request
+type Error = message.Error
+
+// KickError represents the client has been kicked by the server
+type KickError struct {
+	Reason KickReason
+}
+
+func (e *KickError) Error() string {
+	return fmt.Sprintf("kicked by server: %s (code: %d)", e.Reason.Message, e.Reason.Code)
+}
@@ -0,0 +1,106 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package client
+
+import (
+	"time"
+
+	"github.com/lonng/nano/serialize"
+	"github.com/lonng/nano/serialize/protobuf"
+)
+
+type (
+	options struct {
+		dialer        Dialer               // dial the connection to server
+		serializer    serialize.Serializer // serialize the request and response
+		clientType    string               // client type in the handshake
+		version       string               // client version in the handshake
+		userData      interface{}          // user data in the handshake
+		retryInterval time.Duration        // interval to reconnect after connection broken
+		onKick        func(KickReason)     // called when kicked by server
+		onDisconnect  func(err error)      // called when connection broken
+	}
+
+	// Option used to customize client
+	Option func(options *options)
+)
+
+func defaultOptions() options {
+	return options{
+		dialer:     TCPDialer(),
+		serializer: protobuf.NewSerializer(),
+		clientType: "go",
+		version:    Version,
+	}
+}
+
+// WithDialer sets the dialer used to establish the connection, TCPDialer and
+// WebSocketDialer are provided
+func WithDialer(dialer Dialer) Option {
+	return func(opt *options) {
+		opt.dialer = dialer
+	}
+}
+
+// WithSerializer customizes the serializer, which should be consistent with
+// the server, protobuf serializer is used by default
+func WithSerializer(serializer serialize.Serializer) Option {
+	return func(opt *options) {
+		opt.serializer = serializer
+	}
+}
+
+// WithClientType sets the client type reported in the handshake
+func WithClientType(typ string) Option {
+	return func(opt *options) {
+		opt.clientType = typ
+	}
+}
+
+// WithHandshakeData sets the user data of the handshake, which will be
+// encoded to JSON and verified by the handshake validator of server
+func WithHandshakeData(v interface{}) Option {
+	return func(opt *options) {
+		opt.userData = v
+	}
+}
+
+// WithReconnect reconnects to the server after the connection broken, the
+// session will be resumed if the resume window of server is enabled
+func WithReconnect(retryInterval time.Duration) Option {
+	return func(opt *options) {
+		opt.retryInterval = retryInterval
+	}
+}
+
+// WithKickHandler sets the function called when the client kicked by server
+func WithKickHandler(fn func(KickReason)) Option {
+	return func(opt *options) {
+		opt.onKick = fn
+	}
+}
+
+// WithDisconnectHandler sets the function called when the connection broken
+func WithDisconnectHandler(fn func(err error)) Option {
+	return func(opt *options) {
+		opt.onDisconnect = fn
+	}
+}
@@ -3,26 +3,38 @@ package cluster
 import (
 	"context"
 	"net"
+	"sync/atomic"
 
 	"github.com/lonng/nano/cluster/clusterpb"
 	"github.com/lonng/nano/internal/message"
 	"github.com/lonng/nano/mock"
 	"github.com/lonng/nano/session"
+	"github.com/lonng/nano/tracing"
 )
 
 type acceptor struct {
-	sid        int64
-	gateClient clusterpb.MemberClient
-	session    *session.Session
-	lastMid    uint64
-	rpcHandler rpcHandler
-	gateAddr   string
+	node        *Node
+	sid         int64
+	uid         int64 // UID known by the gate, accessed atomically
+	gateClient  clusterpb.MemberClient
+	session     *session.Session
+	lastMid     uint64
+	rpcHandler  rpcHandler
+	callHandler callHandler
+	calls       *pendingCalls
+	gateAddr    string
+	ctx         context.Context     // canceled once the session closed
+	cancel      context.CancelFunc  // cancel the session context
+	lastSpan    tracing.SpanContext // span of the last message handled
+	batcher     *batcher            // nil if the messages are not batched
+	streams     *streams            // streams opened by the gates
+	requests    requests            // requests being handled
+	migrated    atomic.Value        // address of the member which the session migrated to
 }
 
 // Push implements the session.NetworkEntity interface
 func (a *acceptor) Push(route string, v interface{}) error {
-	// TODO: buffer
-	data, err := message.Serialize(v)
+	data, err := a.node.serialize(v)
 	if err != nil {
 		return err
 	}
@@ -31,6 +43,14 @@ func (a *acceptor) Push(route string, v interface{}) error {
 		Route:     route,
 		Data:      data,
 	}
+	if s := a.stream(); s != nil {
+		// The batched messages are sent before the ones sent by the stream
+		a.flush()
+		return s.Send(&clusterpb.StreamMessage{Push: request})
+	}
+	if a.batcher != nil && a.batcher.add(&clusterpb.BatchEntry{Push: request}, len(route)+len(data)) {
+		return nil
+	}
 	_, err = a.gateClient.HandlePush(context.Background(), request)
 	return err
 }
@@ -38,7 +58,7 @@ func (a *acceptor) Push(route string, v interface{}) error {
 // RPC implements the session.NetworkEntity interface
 func (a *acceptor) RPC(route string, v interface{}) error {
 	// TODO: buffer
-	data, err := message.Serialize(v)
+	data, err := a.node.serialize(v)
 	if err != nil {
 		return err
 	}
@@ -51,6 +71,11 @@ func (a *acceptor) RPC(route string, v interface{}) error {
 	return nil
 }
 
+// Call implements the session.Caller interface
+func (a *acceptor) Call(ctx context.Context, route string, v, reply interface{}) error {
+	return a.callHandler(ctx, a.session, route, v, reply)
+}
+
 // LastMid implements the session.NetworkEntity interface
 func (a *acceptor) LastMid() uint64 {
 	return a.lastMid
@@ -63,8 +88,19 @@ func (a *acceptor) Response(v interface{}) error {
 
 // ResponseMid implements the session.NetworkEntity interface
 func (a *acceptor) ResponseMid(mid uint64, v interface{}) error {
-	// TODO: buffer
-	data, err := message.Serialize(v)
+	if ok, err := a.calls.reply(mid, v); ok {
+		return err
+	}
+	a.requests.respond(mid)
+
+	var data []byte
+	var err error
+	e, isError := v.(*message.Error)
+	if isError {
+		data, err = message.EncodeError(e)
+	} else {
+		data, err = a.node.serialize(v)
+	}
 	if err != nil {
 		return err
 	}
@@ -72,22 +108,113 @@ func (a *acceptor) ResponseMid(mid uint64, v interface{}) error {
 		SessionId: a.sid,
 		Id:        mid,
 		Data:      data,
+		Error:     isError,
+	}
+	if mid == a.lastMid {
+		request.Metadata = tracing.Inject(nil, a.lastSpan)
+	}
+	if s := a.stream(); s != nil {
+		a.flush()
+		return s.Send(&clusterpb.StreamMessage{Response: request})
+	}
+	if a.batcher != nil && a.batcher.add(&clusterpb.BatchEntry{Response: request}, len(data)) {
+		return nil
 	}
 	_, err = a.gateClient.HandleResponse(context.Background(), request)
 	return err
 }
 
+// Kick implements the session.Kicker interface
+func (a *acceptor) Kick(reason session.KickReason) error {
+	a.flush()
+	request := &clusterpb.KickSessionRequest{
+		SessionId: a.sid,
+		Code:      int32(reason.Code),
+		Message:   reason.Message,
+	}
+	if s := a.stream(); s != nil {
+		return s.Send(&clusterpb.StreamMessage{Kick: request})
+	}
+	_, err := a.gateClient.KickSession(context.Background(), request)
+	return err
+}
+
 // Close implements the session.NetworkEntity interface
 func (a *acceptor) Close() error {
-	// TODO: buffer
+	a.flush()
 	request := &clusterpb.CloseSessionRequest{
 		SessionId: a.sid,
 	}
+	if s := a.stream(); s != nil {
+		return s.Send(&clusterpb.StreamMessage{Close: request})
+	}
 	_, err := a.gateClient.CloseSession(context.Background(), request)
 	return err
 }
 
+// Bind propagates the UID bound on current node to the gate, and then to the
+// other backends with the messages forwarded from the gate. The UID is known
+// by the gate only if it is propagated successfully.
+func (a *acceptor) Bind(uid int64) error {
+	if atomic.LoadInt64(&a.uid) == uid {
+		return nil
+	}
+	a.flush()
+	request := &clusterpb.BindSessionRequest{
+		SessionId: a.sid,
+		Uid:       uid,
+	}
+	var err error
+	if s := a.stream(); s != nil {
+		err = s.Send(&clusterpb.StreamMessage{Bind: request})
+	} else {
+		_, err = a.gateClient.BindSession(context.Background(), request)
+	}
+	if err != nil {
+		return err
+	}
+	atomic.StoreInt64(&a.uid, uid)
+	return nil
+}
+
+// migratedTo returns the address of the member which the session migrated to,
+// or empty if the session is not migrated
+func (a *acceptor) migratedTo() string {
+	target, _ := a.migrated.Load().(string)
+	return target
+}
+
+// rebind asks the gate to rebind the services bound to addr to target, or to
+// the other members if target is empty
+func (a *acceptor) rebind(addr, target string) error {
+	a.flush()
+	request := &clusterpb.RebindSessionRequest{
+		SessionId:   a.sid,
+		ServiceAddr: addr,
+		Target:      target,
+	}
+	// The rebind is confirmed by the gate with the unary call rather than sent
+	// through the stream
+	_, err := a.gateClient.RebindSession(context.Background(), request)
+	return err
+}
+
 // RemoteAddr implements the session.NetworkEntity interface
 func (*acceptor) RemoteAddr() net.Addr {
 	return mock.NetAddr{}
 }
+
+// stream returns the stream opened by the gate, or nil if absent
+func (a *acceptor) stream() *stream {
+	if a.streams == nil {
+		return nil
+	}
+	return a.streams.get(a.gateAddr)
+}
+
+// flush sends the pending messages to the gate before the session is closed
+func (a *acceptor) flush() {
+	if a.batcher != nil {
+		a.batcher.flush()
+	}
+}
@@ -21,20 +21,21 @@
 package cluster
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"reflect"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/lonng/nano/internal/codec"
-	"github.com/lonng/nano/internal/env"
 	"github.com/lonng/nano/internal/log"
 	"github.com/lonng/nano/internal/message"
 	"github.com/lonng/nano/internal/packet"
 	"github.com/lonng/nano/pipeline"
-	"github.com/lonng/nano/scheduler"
 	"github.com/lonng/nano/session"
 )
 
@@ -54,6 +55,7 @@ type (
 	// Agent corresponding a user, used for store raw conn information
 	agent struct {
 		// regular agent member
+		node     *Node               // node which the agent belongs to
 		session  *session.Session    // session
 		conn     net.Conn            // low-level conn fd
 		lastMid  uint64              // last message id
@@ -64,8 +66,24 @@ type (
 		decoder  *codec.Decoder      // binary decoder
 		pipeline pipeline.Pipeline
 
-		rpcHandler rpcHandler
-		srv        reflect.Value // cached session reflect.Value
+		rpcHandler  rpcHandler
+		callHandler callHandler
+		calls       *pendingCalls
+		srv         reflect.Value      // cached session reflect.Value
+		ctx         context.Context    // canceled once the agent closed
+		cancel      context.CancelFunc // cancel the agent context
+
+		// session resumption
+		resumable bool        // whether the session can be resumed after connection broken
+		token     string      // resume token
+		mu        sync.Mutex  // protect following fields
+		seq       uint64      // sequence of the last data packet
+		written   uint64      // sequence of the last data packet written to the client
+		outbox    []outgoing  // recent data packets
+		successor *agent      // agent which resumed the session
+		expiry    *time.Timer // closes the parked agent after the resume window
+
+		requests requests // requests being handled
 	}
 
 	pendingMessage struct {
@@ -73,24 +91,31 @@ type (
 		route   string       // message route(push)
 		mid     uint64       // response message id(response)
 		payload interface{}  // payload
+		seq     uint64       // sequence of the encoded packet(replay)
+		packet  []byte       // encoded packet(replay and kick)
+		kick    bool         // whether the packet is a kick packet
 	}
 )
 
 // Create new agent instance
-func newAgent(conn net.Conn, pipeline pipeline.Pipeline, rpcHandler rpcHandler) *agent {
+func newAgent(node *Node, conn net.Conn, pipeline pipeline.Pipeline, rpcHandler rpcHandler, callHandler callHandler, calls *pendingCalls) *agent {
 	a := &agent{
-		conn:       conn,
-		state:      statusStart,
-		chDie:      make(chan struct{}),
-		lastAt:     time.Now().Unix(),
-		chSend:     make(chan pendingMessage, agentWriteBacklog),
-		decoder:    codec.NewDecoder(),
-		pipeline:   pipeline,
-		rpcHandler: rpcHandler,
+		node:        node,
+		conn:        conn,
+		state:       statusStart,
+		chDie:       make(chan struct{}),
+		lastAt:      time.Now().Unix(),
+		chSend:      make(chan pendingMessage, agentWriteBacklog),
+		decoder:     codec.NewDecoder(),
+		pipeline:    pipeline,
+		rpcHandler:  rpcHandler,
+		callHandler: callHandler,
+		calls:       calls,
 	}
+	a.ctx, a.cancel = context.WithCancel(context.Background())
 
 	// binding session
-	s := session.New(a)
+	s := node.newSession(a)
 	a.session = s
 	a.srv = reflect.ValueOf(s)
 
@@ -114,15 +139,22 @@ func (a *agent) LastMid() uint64 {
 
 // Push, implementation for session.NetworkEntity interface
 func (a *agent) Push(route string, v interface{}) error {
+	if a.status() == statusParked {
+		return a.buffer(pendingMessage{typ: message.Push, route: route, payload: v})
+	}
 	if a.status() == statusClosed {
+		if successor := a.resumedBy(); successor != nil {
+			return successor.Push(route, v)
+		}
 		return ErrBrokenPipe
 	}
 
 	if len(a.chSend) >= agentWriteBacklog {
+		a.node.metrics.bufferExceeded.Inc()
 		return ErrBufferExceed
 	}
 
-	if env.Debug {
+	if a.node.Debug {
 		switch d := v.(type) {
 		case []byte:
 			log.Println(fmt.Sprintf("Type=Push, ID=%d, UID=%d, Route=%s, Data=%dbytes",
@@ -143,7 +175,7 @@ func (a *agent) RPC(route string, v interface{}) error {
 	}
 
 	// TODO: buffer
-	data, err := message.Serialize(v)
+	data, err := a.node.serialize(v)
 	if err != nil {
 		return err
 	}
@@ -156,6 +188,14 @@ func (a *agent) RPC(route string, v interface{}) error {
 	return nil
 }
 
+// Call, implementation for session.Caller interface
+func (a *agent) Call(ctx context.Context, route string, v, reply interface{}) error {
+	if a.status() == statusClosed {
+		return ErrBrokenPipe
+	}
+	return a.callHandler(ctx, a.session, route, v, reply)
+}
+
 // Response, implementation for session.NetworkEntity interface
 // Response message to session
 func (a *agent) Response(v interface{}) error {
@@ -165,7 +205,18 @@ func (a *agent) Response(v interface{}) error {
 // ResponseMid, implementation for session.NetworkEntity interface
 // Response message to session
 func (a *agent) ResponseMid(mid uint64, v interface{}) error {
+	if ok, err := a.calls.reply(mid, v); ok {
+		return err
+	}
+	a.requests.respond(mid)
+
+	if mid > 0 && a.status() == statusParked {
+		return a.buffer(pendingMessage{typ: message.Response, mid: mid, payload: v})
+	}
 	if a.status() == statusClosed {
+		if successor := a.resumedBy(); successor != nil {
+			return successor.ResponseMid(mid, v)
+		}
 		return ErrBrokenPipe
 	}
 
@@ -174,10 +225,11 @@ func (a *agent) ResponseMid(mid uint64, v interface{}) error {
 	}
 
 	if len(a.chSend) >= agentWriteBacklog {
+		a.node.metrics.bufferExceeded.Inc()
 		return ErrBufferExceed
 	}
 
-	if env.Debug {
+	if a.node.Debug {
 		switch d := v.(type) {
 		case []byte:
 			log.Println(fmt.Sprintf("Type=Response, ID=%d, UID=%d, MID=%d, Data=%dbytes",
@@ -191,6 +243,39 @@ func (a *agent) ResponseMid(mid uint64, v interface{}) error {
 	return a.send(pendingMessage{typ: message.Response, mid: mid, payload: v})
 }
 
+// Kick, implementation for session.Kicker interface
+// Kick sends a kick packet after the pending messages flushed and closes the agent
+func (a *agent) Kick(reason session.KickReason) error {
+	switch a.status() {
+	case statusParked:
+		// No connection to send the kick packet, the remote servers will
+		// be notified when the parked session released
+		a.Close()
+		return nil
+	case statusClosed:
+		if successor := a.resumedBy(); successor != nil {
+			return successor.Kick(reason)
+		}
+		return ErrBrokenPipe
+	}
+
+	data, err := json.Marshal(reason)
+	if err != nil {
+		return err
+	}
+	p, err := codec.Encode(packet.Kick, data)
+	if err != nil {
+		return err
+	}
+
+	if a.node.Debug {
+		log.Println(fmt.Sprintf("Type=Kick, ID=%d, UID=%d, Code=%d, Message=%s",
+			a.session.ID(), a.session.UID(), reason.Code, reason.Message))
+	}
+
+	return a.send(pendingMessage{packet: p, kick: true})
+}
+
 // Close, implementation for session.NetworkEntity interface
 // Close closes the agent, clean inner state and close low-level connection.
 // Any blocked Read or Write operations will be unblocked and return errors.
@@ -199,8 +284,9 @@ func (a *agent) Close() error {
 		return ErrCloseClosedSession
 	}
 	a.setStatus(statusClosed)
+	a.cancel()
 
-	if env.Debug {
+	if a.node.Debug {
 		log.Println(fmt.Sprintf("Session closed, ID=%d, UID=%d, IP=%s",
 			a.session.ID(), a.session.UID(), a.conn.RemoteAddr()))
 	}
@@ -211,7 +297,7 @@ func (a *agent) Close() error {
 		// expect
 	default:
 		close(a.chDie)
-		scheduler.PushTask(func() { session.Lifetime.Close(a.session) })
+		a.node.closeSession(a.session)
 	}
 
 	return a.conn.Close()
@@ -237,15 +323,24 @@ func (a *agent) setStatus(state int32) {
 }
 
 func (a *agent) write() {
-	ticker := time.NewTicker(env.Heartbeat)
+	ticker := time.NewTicker(a.node.Heartbeat)
 	chWrite := make(chan []byte, agentWriteBacklog)
 	// clean func
 	defer func() {
 		ticker.Stop()
-		close(a.chSend)
-		close(chWrite)
-		a.Close()
-		if env.Debug {
+		if a.resumable {
+			// Keep the unsent messages and leave the session to be parked
+			// by the read goroutine
+			a.drain()
+			close(a.chSend)
+			close(chWrite)
+			a.conn.Close()
+		} else {
+			close(a.chSend)
+			close(chWrite)
+			a.Close()
+		}
+		if a.node.Debug {
 			log.Println(fmt.Sprintf("Session write goroutine exit, SessionID=%d, UID=%d", a.session.ID(), a.session.UID()))
 		}
 	}()
@@ -253,12 +348,12 @@ func (a *agent) write() {
 	for {
 		select {
 		case <-ticker.C:
-			deadline := time.Now().Add(-2 * env.Heartbeat).Unix()
+			deadline := time.Now().Add(-2 * a.node.Heartbeat).Unix()
 			if atomic.LoadInt64(&a.lastAt) < deadline {
 				log.Println(fmt.Sprintf("Session heartbeat timeout, LastTime=%d, Deadline=%d", atomic.LoadInt64(&a.lastAt), deadline))
 				return
 			}
-			chWrite <- hbd
+			chWrite <- a.node.handler.hbd
 
 		case data := <-chWrite:
 			// close agent while low-level conn broken
@@ -268,53 +363,95 @@ func (a *agent) write() {
 			}
 
 		case data := <-a.chSend:
-			payload, err := message.Serialize(data.payload)
-			if err != nil {
-				switch data.typ {
-				case message.Push:
-					log.Println(fmt.Sprintf("Push: %s error: %s", data.route, err.Error()))
-				case message.Response:
-					log.Println(fmt.Sprintf("Response message(id: %d) error: %s", data.mid, err.Error()))
-				default:
-					// expect
+			if data.kick {
+				// flush the pending data before kick
+				for len(chWrite) > 0 {
+					if _, err := a.conn.Write(<-chWrite); err != nil {
+						log.Println(err.Error())
+						return
+					}
 				}
-				break
-			}
-
-			// construct message and encode
-			m := &message.Message{
-				Type:  data.typ,
-				Data:  payload,
-				Route: data.route,
-				ID:    data.mid,
-			}
-			if pipe := a.pipeline; pipe != nil {
-				err := pipe.Outbound().Process(a.session, m)
-				if err != nil {
-					log.Println("broken pipeline", err.Error())
-					break
+				if _, err := a.conn.Write(data.packet); err != nil {
+					log.Println(err.Error())
 				}
+				a.Close()
+				return
 			}
-
-			em, err := m.Encode()
-			if err != nil {
-				log.Println(err.Error())
+			if data.packet != nil {
+				a.mu.Lock()
+				a.written = data.seq
+				a.mu.Unlock()
+				chWrite <- data.packet
 				break
 			}
 
-			// packet encode
-			p, err := codec.Encode(packet.Data, em)
+			p, err := a.encode(data)
 			if err != nil {
-				log.Println(err)
 				break
 			}
+			if a.resumable {
+				a.record(p)
+			}
 			chWrite <- p
 
 		case <-a.chDie: // agent closed signal
 			return
 
-		case <-env.Die: // application quit
+		case <-a.node.die: // node shutdown
 			return
 		}
 	}
 }
+
+// encode encodes the pending message to a data packet
+func (a *agent) encode(data pendingMessage) ([]byte, error) {
+	var payload []byte
+	var err error
+	e, isError := data.payload.(*message.Error)
+	if isError {
+		payload, err = message.EncodeError(e)
+	} else {
+		payload, err = a.node.serialize(data.payload)
+	}
+	if err != nil {
+		switch data.typ {
+		case message.Push:
+			log.Println(fmt.Sprintf("Push: %s error: %s", data.route, err.Error()))
+		case message.Response:
+			log.Println(fmt.Sprintf("Response message(id: %d) error: %s", data.mid, err.Error()))
+		default:
+			// expect
+		}
+		return nil, err
+	}
+
+	// construct message and encode
+	m := &message.Message{
+		Type:  data.typ,
+		Data:  payload,
+		Route: data.route,
+		ID:    data.mid,
+		Err:   isError,
+	}
+	if pipe := a.pipeline; pipe != nil {
+		err := pipe.Outbound().Process(a.session, m)
+		if err != nil {
+			log.Println("broken pipeline", err.Error())
+			return nil, err
+		}
+	}
+
+	em, err := a.node.dict.Encode(m)
+	if err != nil {
+		log.Println(err.Error())
+		return nil, err
+	}
+
+	// packet encode
+	p, err := codec.Encode(packet.Data, em)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	return p, nil
+}
@@ -0,0 +1,276 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cluster
+
+import (
+	"fmt"
+	"hash/crc32"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/lonng/nano/cluster/clusterpb"
+	"github.com/lonng/nano/session"
+)
+
+type (
+	// LoadFunc returns the load reported by the member of the service address,
+	// or nil if the member has not reported yet
+	LoadFunc func(serviceAddr string) *clusterpb.MemberLoad
+
+	// Balancer selects the member which serves the service for the session
+	// among members, the selection will be bound to the session router
+	Balancer interface {
+		Select(service string, session *session.Session, members []*clusterpb.MemberInfo, load LoadFunc) *clusterpb.MemberInfo
+	}
+
+	// BalancerFunc is an adapter to allow the use of an ordinary function as
+	// a balancer
+	BalancerFunc func(service string, session *session.Session, members []*clusterpb.MemberInfo, load LoadFunc) *clusterpb.MemberInfo
+)
+
+// Select implements the Balancer interface
+func (fn BalancerFunc) Select(service string, session *session.Session, members []*clusterpb.MemberInfo, load LoadFunc) *clusterpb.MemberInfo {
+	return fn(service, session, members, load)
+}
+
+// Random returns a balancer which selects a member randomly
+func Random() Balancer {
+	return BalancerFunc(func(_ string, _ *session.Session, members []*clusterpb.MemberInfo, _ LoadFunc) *clusterpb.MemberInfo {
+		return members[rand.Intn(len(members))]
+	})
+}
+
+// replicas is the number of virtual nodes of a member on the hash ring
+const replicas = 160
+
+type hashRing struct {
+	key    string   // members the ring is built for
+	hashes []uint32 // sorted hashes of the virtual nodes
+	addrs  map[uint32]string
+}
+
+type consistentHash struct {
+	key      string
+	fallback Balancer
+	mu       sync.Mutex
+	rings    map[string]*hashRing // rings indexed by service
+}
+
+// ConsistentHash returns a balancer which maps the sessions to the members on
+// a hash ring, the sessions with the same key are always served by the same
+// member unless the member leaves. The hash key is the value of the session
+// key, or the UID of the session if key is empty. The sessions without a hash
+// key, i.e. not bound or without the value, are served by the member selected
+// by fallback, or no member if fallback is nil.
+func ConsistentHash(key string, fallback Balancer) Balancer {
+	return &consistentHash{key: key, fallback: fallback, rings: map[string]*hashRing{}}
+}
+
+// Select implements the Balancer interface
+func (c *consistentHash) Select(service string, session *session.Session, members []*clusterpb.MemberInfo, load LoadFunc) *clusterpb.MemberInfo {
+	var key string
+	if c.key == "" {
+		if uid := session.UID(); uid != 0 {
+			key = strconv.FormatInt(uid, 10)
+		}
+	} else if v := session.Value(c.key); v != nil {
+		key = fmt.Sprint(v)
+	}
+	if key == "" {
+		if c.fallback == nil {
+			return nil
+		}
+		return c.fallback.Select(service, session, members, load)
+	}
+
+	addr := c.ring(service, members).lookup(crc32.ChecksumIEEE([]byte(key)))
+	for _, m := range members {
+		if m.ServiceAddr == addr {
+			return m
+		}
+	}
+	return nil
+}
+
+// ring returns the hash ring of the members, which is rebuilt once the members
+// of the service changed
+func (c *consistentHash) ring(service string, members []*clusterpb.MemberInfo) *hashRing {
+	addrs := make([]string, 0, len(members))
+	for _, m := range members {
+		addrs = append(addrs, m.ServiceAddr)
+	}
+	sort.Strings(addrs)
+	key := strings.Join(addrs, ",")
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if r, found := c.rings[service]; found && r.key == key {
+		return r
+	}
+	r := &hashRing{key: key, addrs: map[uint32]string{}}
+	for _, addr := range addrs {
+		for i := 0; i < replicas; i++ {
+			h := crc32.ChecksumIEEE([]byte(addr + "#" + strconv.Itoa(i)))
+			if _, found := r.addrs[h]; found {
+				continue
+			}
+			r.addrs[h] = addr
+			r.hashes = append(r.hashes, h)
+		}
+	}
+	sort.Slice(r.hashes, func(i, j int) bool { return r.hashes[i] < r.hashes[j] })
+	c.rings[service] = r
+	return r
+}
+
+func (r *hashRing) lookup(h uint32) string {
+	i := sort.Search(len(r.hashes), func(i int) bool { return r.hashes[i] >= h })
+	if i == len(r.hashes) {
+		i = 0
+	}
+	return r.addrs[r.hashes[i]]
+}
+
+type weightedRoundRobin struct {
+	mu      sync.Mutex
+	current map[string]map[string]int // current weights indexed by service and member
+}
+
+// WeightedRoundRobin returns a balancer which selects the members in turn in
+// proportion to the weights advertised by the members, the weight of a member
+// is 1 if not advertised
+func WeightedRoundRobin() Balancer {
+	return &weightedRoundRobin{current: map[string]map[string]int{}}
+}
+
+// Select implements the Balancer interface, it follows the smooth weighted
+// round-robin of nginx, which interleaves the members evenly
+func (w *weightedRoundRobin) Select(service string, _ *session.Session, members []*clusterpb.MemberInfo, _ LoadFunc) *clusterpb.MemberInfo {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	current, found := w.current[service]
+	if !found {
+		current = map[string]int{}
+		w.current[service] = current
+	}
+
+	var best *clusterpb.MemberInfo
+	total := 0
+	for _, m := range members {
+		weight := int(m.Weight)
+		if weight <= 0 {
+			weight = 1
+		}
+		total += weight
+		current[m.ServiceAddr] += weight
+		if best == nil || current[m.ServiceAddr] > current[best.ServiceAddr] {
+			best = m
+		}
+	}
+	current[best.ServiceAddr] -= total
+
+	// Forget the members which have left
+	if len(current) > len(members) {
+		alive := map[string]bool{}
+		for _, m := range members {
+			alive[m.ServiceAddr] = true
+		}
+		for addr := range current {
+			if !alive[addr] {
+				delete(current, addr)
+			}
+		}
+	}
+	return best
+}
+
+type assignment struct {
+	reportedAt int64 // time of the load report the sessions are assigned after
+	sessions   int64
+}
+
+type leastSessions struct {
+	mu       sync.Mutex
+	assigned map[string]map[string]*assignment // assignments indexed by service and member
+}
+
+// LeastSessions returns a balancer which selects the member with the fewest
+// sessions, which are the sessions reported in the heartbeats and the ones
+// assigned by current node since the report. The members which have not
+// reported are regarded as idle, and a tie is broken randomly
+func LeastSessions() Balancer {
+	return &leastSessions{assigned: map[string]map[string]*assignment{}}
+}
+
+// Select implements the Balancer interface
+func (l *leastSessions) Select(service string, _ *session.Session, members []*clusterpb.MemberInfo, load LoadFunc) *clusterpb.MemberInfo {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	assigned, found := l.assigned[service]
+	if !found {
+		assigned = map[string]*assignment{}
+		l.assigned[service] = assigned
+	}
+
+	var candidates []*clusterpb.MemberInfo
+	least := int64(-1)
+	for _, m := range members {
+		var sessions, reportedAt int64
+		if r := load(m.ServiceAddr); r != nil {
+			sessions, reportedAt = r.Sessions, r.ReportedAt
+		}
+		// The sessions assigned before the report are counted in the report
+		a, found := assigned[m.ServiceAddr]
+		if !found || a.reportedAt != reportedAt {
+			a = &assignment{reportedAt: reportedAt}
+			assigned[m.ServiceAddr] = a
+		}
+		sessions += a.sessions
+		switch {
+		case least < 0 || sessions < least:
+			least = sessions
+			candidates = append(candidates[:0], m)
+		case sessions == least:
+			candidates = append(candidates, m)
+		}
+	}
+	selected := candidates[rand.Intn(len(candidates))]
+	assigned[selected.ServiceAddr].sessions++
+
+	// Forget the members which have left
+	if len(assigned) > len(members) {
+		alive := map[string]bool{}
+		for _, m := range members {
+			alive[m.ServiceAddr] = true
+		}
+		for addr := range assigned {
+			if !alive[addr] {
+				delete(assigned, addr)
+			}
+		}
+	}
+	return selected
+}
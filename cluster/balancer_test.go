@@ -0,0 +1,101 @@
+package cluster_test
+
+import (
+	"github.com/lonng/nano/cluster"
+	"github.com/lonng/nano/cluster/clusterpb"
+	"github.com/lonng/nano/session"
+	. "github.com/pingcap/check"
+)
+
+func (s *nodeSuite) TestConsistentHash(c *C) {
+	members := []*clusterpb.MemberInfo{
+		{ServiceAddr: "127.0.0.1:1"},
+		{ServiceAddr: "127.0.0.1:2"},
+		{ServiceAddr: "127.0.0.1:3"},
+	}
+	balancer := cluster.ConsistentHash("", nil)
+
+	selected := map[int64]string{}
+	counts := map[string]int{}
+	for uid := int64(1); uid <= 300; uid++ {
+		sess := session.New(nil)
+		c.Assert(sess.Bind(uid), IsNil)
+		addr := balancer.Select("Game", sess, members, nil).ServiceAddr
+		c.Assert(balancer.Select("Game", sess, members, nil).ServiceAddr, Equals, addr)
+		selected[uid] = addr
+		counts[addr]++
+	}
+	c.Assert(counts, HasLen, 3)
+
+	// Only the sessions of the member left are remapped
+	for uid, addr := range selected {
+		sess := session.New(nil)
+		c.Assert(sess.Bind(uid), IsNil)
+		remapped := balancer.Select("Game", sess, members[:2], nil).ServiceAddr
+		if addr != members[2].ServiceAddr {
+			c.Assert(remapped, Equals, addr)
+		}
+	}
+
+	// The sessions without the hash key are not served without a fallback
+	c.Assert(balancer.Select("Game", session.New(nil), members, nil), IsNil)
+
+	// Hash on the session value
+	balancer = cluster.ConsistentHash("room", cluster.WeightedRoundRobin())
+	a, b := session.New(nil), session.New(nil)
+	a.Set("room", "lobby")
+	b.Set("room", "lobby")
+	c.Assert(balancer.Select("Game", a, members, nil), Equals, balancer.Select("Game", b, members, nil))
+
+	// The sessions without the hash key are spread by the fallback
+	counts = map[string]int{}
+	for i := 0; i < 3; i++ {
+		counts[balancer.Select("Game", session.New(nil), members, nil).ServiceAddr]++
+	}
+	c.Assert(counts, HasLen, 3)
+}
+
+func (s *nodeSuite) TestWeightedRoundRobin(c *C) {
+	members := []*clusterpb.MemberInfo{
+		{ServiceAddr: "127.0.0.1:1", Weight: 3},
+		{ServiceAddr: "127.0.0.1:2", Weight: 1},
+		{ServiceAddr: "127.0.0.1:3"},
+	}
+	balancer := cluster.WeightedRoundRobin()
+
+	var selected []string
+	for i := 0; i < 5; i++ {
+		selected = append(selected, balancer.Select("Game", nil, members, nil).ServiceAddr)
+	}
+	c.Assert(selected, DeepEquals, []string{"127.0.0.1:1", "127.0.0.1:2", "127.0.0.1:1", "127.0.0.1:3", "127.0.0.1:1"})
+}
+
+func (s *nodeSuite) TestLeastSessions(c *C) {
+	members := []*clusterpb.MemberInfo{
+		{ServiceAddr: "127.0.0.1:1"},
+		{ServiceAddr: "127.0.0.1:2"},
+		{ServiceAddr: "127.0.0.1:3"},
+	}
+	loads := map[string]*clusterpb.MemberLoad{
+		"127.0.0.1:1": {Sessions: 10},
+		"127.0.0.1:2": {Sessions: 3},
+		"127.0.0.1:3": {Sessions: 7},
+	}
+	load := func(addr string) *clusterpb.MemberLoad { return loads[addr] }
+	balancer := cluster.LeastSessions()
+	c.Assert(balancer.Select("Game", nil, members, load).ServiceAddr, Equals, "127.0.0.1:2")
+
+	// The sessions assigned since the last report are counted
+	for i := 0; i < 3; i++ {
+		c.Assert(balancer.Select("Game", nil, members, load).ServiceAddr, Equals, "127.0.0.1:2")
+	}
+	c.Assert(balancer.Select("Game", nil, members, load).ServiceAddr, Not(Equals), "127.0.0.1:1")
+
+	// The assignments are reset once the member reported again
+	loads["127.0.0.1:2"] = &clusterpb.MemberLoad{Sessions: 3, ReportedAt: 1}
+	c.Assert(balancer.Select("Game", nil, members, load).ServiceAddr, Equals, "127.0.0.1:2")
+
+	// The members which have not reported are regarded as idle
+	delete(loads, "127.0.0.1:3")
+	c.Assert(balancer.Select("Game", nil, members, load).ServiceAddr, Equals, "127.0.0.1:3")
+}
@@ -0,0 +1,149 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lonng/nano/cluster/clusterpb"
+	"github.com/lonng/nano/internal/log"
+	"github.com/lonng/nano/metrics"
+)
+
+// defaultBatchSize is the number of bytes which triggers the flush of a batch
+// if the batch size is not specified
+const defaultBatchSize = 64 * 1024
+
+// batcher coalesces the pushes and responses sent to a gate, and flushes them
+// in one HandleBatch RPC once the batch size reached or the interval elapsed.
+// The batches are sent one by one, so the messages of a session keep order.
+type batcher struct {
+	gateAddr string
+	client   clusterpb.MemberClient
+	interval time.Duration
+	maxSize  int
+	failures *metrics.Counter // number of the messages failed to be flushed
+
+	mu      sync.Mutex
+	entries []*clusterpb.BatchEntry
+	size    int
+	closed  bool
+
+	sendMu sync.Mutex    // serializes the flushes
+	full   chan struct{} // signaled once the batch size reached
+	die    chan struct{}
+	done   chan struct{}
+}
+
+func newBatcher(gateAddr string, client clusterpb.MemberClient, interval time.Duration, maxSize int, failures *metrics.Counter) *batcher {
+	if maxSize <= 0 {
+		maxSize = defaultBatchSize
+	}
+	b := &batcher{
+		gateAddr: gateAddr,
+		client:   client,
+		interval: interval,
+		maxSize:  maxSize,
+		failures: failures,
+		full:     make(chan struct{}, 1),
+		die:      make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go b.run()
+	return b
+}
+
+// add appends the entry to the pending batch, size is the approximate number
+// of bytes of the entry. It returns false if the batcher is closed, then the
+// entry should be sent directly
+func (b *batcher) add(entry *clusterpb.BatchEntry, size int) bool {
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		return false
+	}
+	b.entries = append(b.entries, entry)
+	b.size += size
+	full := b.size >= b.maxSize
+	b.mu.Unlock()
+
+	if full {
+		select {
+		case b.full <- struct{}{}:
+		default:
+		}
+	}
+	return true
+}
+
+// flush sends the pending batch and waits for the gate to handle it
+func (b *batcher) flush() error {
+	b.sendMu.Lock()
+	defer b.sendMu.Unlock()
+
+	b.mu.Lock()
+	entries := b.entries
+	b.entries, b.size = nil, 0
+	b.mu.Unlock()
+
+	if len(entries) == 0 {
+		return nil
+	}
+	_, err := b.client.HandleBatch(context.Background(), &clusterpb.BatchMessage{Entries: entries})
+	if err != nil {
+		log.Println(fmt.Sprintf("Flush %d messages to gate %s error: %s", len(entries), b.gateAddr, err.Error()))
+		if b.failures != nil {
+			b.failures.Add(float64(len(entries)), b.gateAddr)
+		}
+	}
+	return err
+}
+
+func (b *batcher) run() {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			b.flush()
+		case <-b.full:
+			b.flush()
+		case <-b.die:
+			b.flush()
+			return
+		}
+	}
+}
+
+// close flushes the pending batch and stops the batcher, the entries added
+// later are rejected
+func (b *batcher) close() {
+	b.mu.Lock()
+	b.closed = true
+	b.mu.Unlock()
+	close(b.die)
+	<-b.done
+}
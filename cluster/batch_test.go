@@ -0,0 +1,80 @@
+package cluster_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lonng/nano/benchmark/testdata"
+	"github.com/lonng/nano/client"
+	"github.com/lonng/nano/cluster"
+	"github.com/lonng/nano/component"
+	"github.com/lonng/nano/session"
+	. "github.com/pingcap/check"
+)
+
+func (c *GameComponent) Burst(s *session.Session, ping *testdata.Ping) error {
+	for i := 0; i < 10; i++ {
+		if err := s.Push("onBurst", &testdata.Pong{Content: fmt.Sprintf("%s %d", ping.Content, i)}); err != nil {
+			return err
+		}
+	}
+	return s.Response(&testdata.Pong{Content: "done"})
+}
+
+func (s *nodeSuite) TestBatch(c *C) {
+	masterNode := startNode(c, cluster.Options{IsMaster: true})
+	defer masterNode.Shutdown()
+
+	gateNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		ClientAddr:    "127.0.0.1:0",
+	})
+
+	gameComps := &component.Components{}
+	gameComps.Register(&GameComponent{})
+	gameNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		Components:    gameComps,
+		BatchInterval: 20 * time.Millisecond,
+		BatchSize:     64,
+	})
+	defer gameNode.Shutdown()
+
+	cli, err := client.Dial(context.Background(), gateNode.ClientAddr)
+	c.Assert(err, IsNil)
+	defer cli.Close()
+	var mu sync.Mutex
+	var pushed []string
+	cli.On("onBurst", func(data []byte) {
+		pong := &testdata.Pong{}
+		c.Assert(cli.Unmarshal(data, pong), IsNil)
+		mu.Lock()
+		pushed = append(pushed, pong.Content)
+		mu.Unlock()
+	})
+
+	// The pushes are flushed by size and by interval, and arrive before the response
+	for round := 0; round < 2; round++ {
+		mu.Lock()
+		pushed = nil
+		mu.Unlock()
+		pong := &testdata.Pong{}
+		c.Assert(cli.Request(context.Background(), "GameComponent.Burst", &testdata.Ping{Content: "burst"}, pong), IsNil)
+		c.Assert(pong.Content, Equals, "done")
+		mu.Lock()
+		contents := pushed
+		mu.Unlock()
+		c.Assert(contents, HasLen, 10)
+		for i, content := range contents {
+			c.Assert(content, Equals, fmt.Sprintf("burst %d", i))
+		}
+	}
+	c.Assert(gameNode.Batchers(), Equals, 1)
+
+	// The batcher is removed once the gate leaves
+	cli.Close()
+	gateNode.Shutdown()
+	waitFor(c, 5*time.Second, func() bool { return gameNode.Batchers() == 0 })
+}
@@ -0,0 +1,217 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cluster
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/lonng/nano/internal/message"
+	"github.com/lonng/nano/mock"
+	"github.com/lonng/nano/serialize"
+	"github.com/lonng/nano/session"
+)
+
+// callIDBase is the lower bound of the message ids allocated for calls, which
+// keeps them apart from the message ids chosen by clients.
+const callIDBase uint64 = 1 << 63
+
+type (
+	callHandler func(ctx context.Context, session *session.Session, route string, v, reply interface{}) error
+
+	callResult struct {
+		data []byte
+		err  error
+	}
+
+	// pendingCalls tracks the calls which are waiting for the response of
+	// a local handler.
+	pendingCalls struct {
+		mu         sync.Mutex
+		seq        uint64
+		replies    map[uint64]chan callResult
+		serializer serialize.Serializer // serializes the responses
+	}
+)
+
+func newPendingCalls(serializer serialize.Serializer) *pendingCalls {
+	return &pendingCalls{replies: map[uint64]chan callResult{}, serializer: serializer}
+}
+
+// add allocates a message id for a call and returns the channel which the
+// call result will be delivered to.
+func (c *pendingCalls) add() (uint64, chan callResult) {
+	ch := make(chan callResult, 1)
+	c.mu.Lock()
+	c.seq++
+	id := callIDBase | c.seq
+	c.replies[id] = ch
+	c.mu.Unlock()
+	return id, ch
+}
+
+func (c *pendingCalls) take(id uint64) (chan callResult, bool) {
+	c.mu.Lock()
+	ch, found := c.replies[id]
+	delete(c.replies, id)
+	c.mu.Unlock()
+	return ch, found
+}
+
+// reply delivers the response of a call, it returns false if mid does not
+// belong to a call and the response should be sent to the client as usual.
+func (c *pendingCalls) reply(mid uint64, v interface{}) (bool, error) {
+	if mid < callIDBase {
+		return false, nil
+	}
+	ch, found := c.take(mid)
+	if !found {
+		// the caller has gone away
+		return true, nil
+	}
+	if e, ok := v.(*message.Error); ok {
+		ch <- callResult{err: e}
+		return true, nil
+	}
+	data, err := message.SerializeWith(c.serializer, v)
+	ch <- callResult{data: data, err: err}
+	return true, err
+}
+
+// fail terminates a call with err, it does nothing if mid does not belong to
+// a pending call.
+func (c *pendingCalls) fail(mid uint64, err error) {
+	if mid < callIDBase {
+		return
+	}
+	if ch, found := c.take(mid); found {
+		ch <- callResult{err: err}
+	}
+}
+
+// wait blocks until the response of the call arrives or ctx is done.
+func (c *pendingCalls) wait(ctx context.Context, id uint64, ch chan callResult) ([]byte, error) {
+	select {
+	case r := <-ch:
+		return r.data, r.err
+	case <-ctx.Done():
+		c.take(id)
+		if ctx.Err() == context.DeadlineExceeded {
+			return nil, ErrCallTimeout
+		}
+		return nil, ctx.Err()
+	}
+}
+
+func decodeReply(serializer serialize.Serializer, data []byte, reply interface{}) error {
+	switch r := reply.(type) {
+	case nil:
+		return nil
+	case *[]byte:
+		*r = data
+		return nil
+	}
+	return serializer.Unmarshal(data, reply)
+}
+
+// callee is the network entity of the sessions which are created for the
+// session-less calls, it only can respond to the call.
+type callee struct {
+	session     *session.Session
+	lastMid     uint64
+	calls       *pendingCalls
+	rpcHandler  rpcHandler
+	callHandler callHandler
+}
+
+// Push implements the session.NetworkEntity interface
+func (*callee) Push(_ string, _ interface{}) error {
+	return ErrSessionNotConnected
+}
+
+// RPC implements the session.NetworkEntity interface
+func (c *callee) RPC(route string, v interface{}) error {
+	data, err := message.SerializeWith(c.calls.serializer, v)
+	if err != nil {
+		return err
+	}
+	msg := &message.Message{
+		Type:  message.Notify,
+		Route: route,
+		Data:  data,
+	}
+	c.rpcHandler(c.session, msg, true)
+	return nil
+}
+
+// Call implements the session.Caller interface
+func (c *callee) Call(ctx context.Context, route string, v, reply interface{}) error {
+	return c.callHandler(ctx, c.session, route, v, reply)
+}
+
+// LastMid implements the session.NetworkEntity interface
+func (c *callee) LastMid() uint64 {
+	return c.lastMid
+}
+
+// Response implements the session.NetworkEntity interface
+func (c *callee) Response(v interface{}) error {
+	return c.ResponseMid(c.lastMid, v)
+}
+
+// ResponseMid implements the session.NetworkEntity interface
+func (c *callee) ResponseMid(mid uint64, v interface{}) error {
+	if ok, err := c.calls.reply(mid, v); ok {
+		return err
+	}
+	return ErrSessionNotConnected
+}
+
+// Kick implements the session.Kicker interface
+func (*callee) Kick(session.KickReason) error {
+	return ErrSessionNotConnected
+}
+
+// Close implements the session.NetworkEntity interface
+func (*callee) Close() error {
+	return nil
+}
+
+// RemoteAddr implements the session.NetworkEntity interface
+func (*callee) RemoteAddr() net.Addr {
+	return mock.NetAddr{}
+}
+
+// Call sends a request to the handler of route on the node which provides the
+// service, waits for the response and unmarshals it into reply. The handler
+// receives a temporary session that only can respond to the call.
+//
+// Call is issued by the node which created ctx, i.e. ctx must be the context of
+// a context-aware handler or derived from it. Call fails with ErrNoNodeContext
+// for the other contexts, e.g. context.Background(), use Node.Call instead.
+func Call(ctx context.Context, route string, v, reply interface{}) error {
+	n, ok := ctx.Value(nodeKey).(*Node)
+	if !ok {
+		return ErrNoNodeContext
+	}
+	return n.Call(ctx, route, v, reply)
+}
@@ -0,0 +1,145 @@
+package cluster_test
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lonng/nano/benchmark/io"
+	"github.com/lonng/nano/benchmark/testdata"
+	"github.com/lonng/nano/cluster"
+	"github.com/lonng/nano/component"
+	"github.com/lonng/nano/internal/message"
+	"github.com/lonng/nano/session"
+	. "github.com/pingcap/check"
+)
+
+func (c *GateComponent) Test3(s *session.Session, ping *testdata.Ping) error {
+	mid := s.LastMid()
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		if err := s.Call(ctx, "GameComponent.Test5", &testdata.Ping{Content: " by gate"}, nil); err != nil {
+			s.ResponseMID(mid, &testdata.Pong{Content: err.Error()})
+			return
+		}
+		pong := &testdata.Pong{}
+		if err := s.Call(ctx, "GameComponent.Test4", ping, pong); err != nil {
+			s.ResponseMID(mid, &testdata.Pong{Content: err.Error()})
+			return
+		}
+		s.ResponseMID(mid, pong)
+	}()
+	return nil
+}
+
+func (c *GateComponent) Test4(ctx context.Context, s *session.Session, ping *testdata.Ping) (*testdata.Pong, error) {
+	// GateComponent.Test2 runs on the scheduler blocked by current handler
+	err := s.Call(ctx, "GateComponent.Test2", ping, &testdata.Pong{})
+	return &testdata.Pong{Content: fmt.Sprint(err)}, nil
+}
+
+// RelayComponent runs on its own scheduler, the default scheduler is shared
+// by the nodes of the tests and would be blocked by the relayed call
+type RelayComponent struct{ component.Base }
+
+func (c *RelayComponent) Relay(ctx context.Context, s *session.Session, ping *testdata.Ping) (*testdata.Pong, error) {
+	pong := &testdata.Pong{}
+	if err := cluster.Call(ctx, "GameComponent.Test2", ping, pong); err != nil {
+		return nil, err
+	}
+	return pong, nil
+}
+
+func (s *nodeSuite) TestNodeCall(c *C) {
+	masterNode := startNode(c, cluster.Options{IsMaster: true})
+	defer masterNode.Shutdown()
+
+	gateComps := &component.Components{}
+	gateComps.Register(&GateComponent{})
+	gateComps.Register(&RelayComponent{}, component.WithNamedScheduler("relay"))
+	gateNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		ClientAddr:    "127.0.0.1:0",
+		Components:    gateComps,
+	})
+	defer gateNode.Shutdown()
+
+	gameComps := &component.Components{}
+	gameComps.Register(&GameComponent{})
+	gameNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		Components:    gameComps,
+	})
+	defer gameNode.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// session-less call from the gate to the game server
+	pong := &testdata.Pong{}
+	err := gateNode.Call(ctx, "GameComponent.Test2", &testdata.Ping{Content: "ping"}, pong)
+	c.Assert(err, IsNil)
+	c.Assert(pong.Content, Equals, "game server pong2")
+
+	// session-less call which is served by the current node
+	err = gameNode.Call(ctx, "GameComponent.Test2", &testdata.Ping{Content: "ping"}, pong)
+	c.Assert(err, IsNil)
+	c.Assert(pong.Content, Equals, "game server pong2")
+
+	err = gateNode.Call(ctx, "GameComponent.Test3", &testdata.Ping{Content: "ping"}, pong)
+	remoteErr, ok := err.(*cluster.RemoteError)
+	c.Assert(ok, IsTrue)
+	c.Assert(remoteErr.Message, Equals, "game server error")
+	c.Assert(remoteErr.Code, Equals, message.ErrCodeInternal)
+
+	err = gateNode.Call(ctx, "GameComponent.Test7", &testdata.Ping{Content: "not enough gold"}, pong)
+	remoteErr, ok = err.(*cluster.RemoteError)
+	c.Assert(ok, IsTrue)
+	c.Assert(remoteErr.Code, Equals, 1001)
+	c.Assert(remoteErr.Message, Equals, "not enough gold")
+
+	err = gateNode.Call(ctx, "GameComponent.Test", &testdata.Ping{Content: "ping"}, pong)
+	remoteErr, ok = err.(*cluster.RemoteError)
+	c.Assert(ok, IsTrue)
+	c.Assert(remoteErr.Message, Equals, cluster.ErrSessionNotConnected.Error())
+
+	timeout, cancelTimeout := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancelTimeout()
+	err = gateNode.Call(timeout, "GameComponent.Test6", &testdata.Ping{Content: "ping"}, pong)
+	c.Assert(err, Equals, cluster.ErrCallTimeout)
+
+	err = gateNode.Call(ctx, "UnknownComponent.Test", &testdata.Ping{Content: "ping"}, pong)
+	c.Assert(err, Equals, cluster.ErrServiceNotFound)
+
+	// The package level call is issued by the node running the handler
+	err = gateNode.Call(ctx, "RelayComponent.Relay", &testdata.Ping{Content: "ping"}, pong)
+	c.Assert(err, IsNil)
+	c.Assert(pong.Content, Equals, "game server pong2")
+	err = cluster.Call(ctx, "GameComponent.Test2", &testdata.Ping{Content: "ping"}, pong)
+	c.Assert(err, Equals, cluster.ErrNoNodeContext)
+
+	// call with the session of a client
+	connector := io.NewConnector()
+	chWait := make(chan struct{})
+	connector.OnConnected(func() {
+		chWait <- struct{}{}
+	})
+	c.Assert(connector.Start(gateNode.ClientAddr), IsNil)
+	<-chWait
+
+	onResult := make(chan string)
+	err = connector.Request("GateComponent.Test3", &testdata.Ping{Content: "ping"}, func(data interface{}) {
+		onResult <- string(data.([]byte))
+	})
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(<-onResult, "ping by gate"), IsTrue)
+
+	// call the handler on the scheduler of the caller
+	err = connector.Request("GateComponent.Test4", &testdata.Ping{Content: "ping"}, func(data interface{}) {
+		onResult <- string(data.([]byte))
+	})
+	c.Assert(err, IsNil)
+	c.Assert(strings.Contains(<-onResult, cluster.ErrCallDeadlock.Error()), IsTrue)
+}
@@ -27,7 +27,6 @@ import (
 	"time"
 
 	"github.com/lonng/nano/cluster/clusterpb"
-	"github.com/lonng/nano/internal/env"
 	"github.com/lonng/nano/internal/log"
 )
 
@@ -41,10 +40,14 @@ type cluster struct {
 
 	mu      sync.RWMutex
 	members []*Member
+
+	election election
+	die      chan struct{}
 }
 
 func newCluster(currentNode *Node) *cluster {
-	c := &cluster{currentNode: currentNode}
+	c := &cluster{currentNode: currentNode, die: make(chan struct{})}
+	c.election.changed = make(chan struct{}, 1)
 	if currentNode.IsMaster {
 		c.checkMemberHeartbeat()
 	}
@@ -56,6 +59,9 @@ func (c *cluster) Register(_ context.Context, req *clusterpb.RegisterRequest) (*
 	if req.MemberInfo == nil {
 		return nil, ErrInvalidRegisterReq
 	}
+	if !c.isLeader() {
+		return nil, ErrNotLeader
+	}
 	resp := &clusterpb.RegisterResponse{}
 	c.mu.Lock()
 	for k, m := range c.members {
@@ -70,16 +76,19 @@ func (c *cluster) Register(_ context.Context, req *clusterpb.RegisterRequest) (*
 			//return nil, fmt.Errorf("address %s has registered", req.MemberInfo.ServiceAddr)
 		}
 	}
+	var notified []string
+	for _, m := range c.members {
+		resp.Members = append(resp.Members, m.memberInfo)
+		if !m.isMaster {
+			notified = append(notified, m.memberInfo.ServiceAddr)
+		}
+	}
 	c.mu.Unlock()
 
 	// Notify registered node to update remote services
 	newMember := &clusterpb.NewMemberRequest{MemberInfo: req.MemberInfo}
-	for _, m := range c.members {
-		resp.Members = append(resp.Members, m.memberInfo)
-		if m.isMaster {
-			continue
-		}
-		pool, err := c.rpcClient.getConnPool(m.memberInfo.ServiceAddr)
+	for _, addr := range notified {
+		pool, err := c.rpcClient.getConnPool(addr)
 		if err != nil {
 			return nil, err
 		}
@@ -92,11 +101,16 @@ func (c *cluster) Register(_ context.Context, req *clusterpb.RegisterRequest) (*
 
 	log.Println("New peer register to cluster", req.MemberInfo.ServiceAddr)
 
-	// Register services to current node
+	// Register services to current node, the stale services are dropped if
+	// the member registers again
+	c.currentNode.handler.delMember(req.MemberInfo.ServiceAddr)
 	c.currentNode.handler.addRemoteService(req.MemberInfo)
 	c.mu.Lock()
 	c.members = append(c.members, &Member{isMaster: false, memberInfo: req.MemberInfo, lastHeartbeatAt: time.Now()})
 	c.mu.Unlock()
+
+	// Replicate the member table to the other masters
+	c.replicateLater()
 	return resp, nil
 }
 
@@ -105,31 +119,36 @@ func (c *cluster) Unregister(_ context.Context, req *clusterpb.UnregisterRequest
 	if req.ServiceAddr == "" {
 		return nil, ErrInvalidRegisterReq
 	}
+	if !c.isLeader() {
+		return nil, ErrNotLeader
+	}
 
-	var index = -1
+	var (
+		member   *Member
+		notified []string
+	)
 	resp := &clusterpb.UnregisterResponse{}
-	for i, m := range c.members {
+	c.mu.RLock()
+	for _, m := range c.members {
 		if m.memberInfo.ServiceAddr == req.ServiceAddr {
-			index = i
-			break
+			unregistered := *m
+			member = &unregistered
+			continue
+		}
+		// Other masters mirror the member table from the leader
+		if !m.isMaster {
+			notified = append(notified, m.memberInfo.ServiceAddr)
 		}
 	}
-	if index < 0 {
+	c.mu.RUnlock()
+	if member == nil {
 		return nil, fmt.Errorf("address %s has not registered", req.ServiceAddr)
 	}
 
 	// Notify registered node to update remote services
 	delMember := &clusterpb.DelMemberRequest{ServiceAddr: req.ServiceAddr}
-	for i, m := range c.members {
-		if i == index {
-			// this node is down.
-			continue
-		}
-
-		if m.MemberInfo().ServiceAddr == c.currentNode.ServiceAddr {
-			continue
-		}
-		pool, err := c.rpcClient.getConnPool(m.memberInfo.ServiceAddr)
+	for _, addr := range notified {
+		pool, err := c.rpcClient.getConnPool(addr)
 		if err != nil {
 			return nil, err
 		}
@@ -143,23 +162,22 @@ func (c *cluster) Unregister(_ context.Context, req *clusterpb.UnregisterRequest
 	log.Println("Exists peer unregister to cluster", req.ServiceAddr)
 
 	if c.currentNode.UnregisterCallback != nil {
-		c.currentNode.UnregisterCallback(*c.members[index])
+		c.currentNode.UnregisterCallback(*member)
 	}
 
 	// Register services to current node
 	c.currentNode.handler.delMember(req.ServiceAddr)
-	c.mu.Lock()
-	if index >= len(c.members)-1 {
-		c.members = c.members[:index]
-	} else {
-		c.members = append(c.members[:index], c.members[index+1:]...)
-	}
-	c.mu.Unlock()
+	c.delMember(req.ServiceAddr)
 
+	// Replicate the member table to the other masters
+	c.replicateLater()
 	return resp, nil
 }
 
 func (c *cluster) Heartbeat(_ context.Context, req *clusterpb.HeartbeatRequest) (*clusterpb.HeartbeatResponse, error) {
+	if !c.isLeader() {
+		return nil, ErrNotLeader
+	}
 	c.mu.Lock()
 	defer c.mu.Unlock()
 
@@ -167,6 +185,7 @@ func (c *cluster) Heartbeat(_ context.Context, req *clusterpb.HeartbeatRequest)
 	for i, m := range c.members {
 		if m.MemberInfo().GetServiceAddr() == req.GetMemberInfo().GetServiceAddr() {
 			c.members[i].lastHeartbeatAt = time.Now()
+			c.members[i].load = req.Load
 			isHit = true
 		}
 	}
@@ -177,23 +196,45 @@ func (c *cluster) Heartbeat(_ context.Context, req *clusterpb.HeartbeatRequest)
 			isMaster:        false,
 			memberInfo:      req.GetMemberInfo(),
 			lastHeartbeatAt: time.Now(),
+			load:            req.Load,
 		}
 		c.members = append(c.members, m)
 		c.currentNode.handler.addRemoteService(req.MemberInfo)
 		log.Println("Heartbeat peer register to cluster", req.MemberInfo.ServiceAddr)
 	}
-	return &clusterpb.HeartbeatResponse{}, nil
+
+	// Share the loads of all members with the member
+	resp := &clusterpb.HeartbeatResponse{Loads: c.loads()}
+	c.currentNode.setLoads(resp.Loads)
+	return resp, nil
+}
+
+// loads returns the loads reported by the members and the load of current
+// node, it must be called with the lock held
+func (c *cluster) loads() []*clusterpb.MemberLoad {
+	var loads []*clusterpb.MemberLoad
+	for _, m := range c.members {
+		if m.load != nil && m.memberInfo.ServiceAddr != c.currentNode.ServiceAddr {
+			loads = append(loads, m.load)
+		}
+	}
+	return append(loads, c.currentNode.Load())
 }
 
 func (c *cluster) checkMemberHeartbeat() {
 	check := func() {
+		if !c.isLeader() {
+			return
+		}
 		unregisterMembers := make([]*Member, 0)
-		// check heartbeat time
+		// check heartbeat time, the other masters are kept alive by the lease
+		c.mu.RLock()
 		for _, m := range c.members {
-			if time.Now().Sub(m.lastHeartbeatAt) > 4*env.Heartbeat && !m.isMaster {
+			if time.Now().Sub(m.lastHeartbeatAt) > 4*c.currentNode.Heartbeat && m.memberInfo.ServiceAddr != c.currentNode.ServiceAddr {
 				unregisterMembers = append(unregisterMembers, m)
 			}
 		}
+		c.mu.RUnlock()
 
 		for _, m := range unregisterMembers {
 			if _, err := c.Unregister(context.Background(), &clusterpb.UnregisterRequest{
@@ -204,7 +245,7 @@ func (c *cluster) checkMemberHeartbeat() {
 		}
 	}
 	go func() {
-		ticker := time.NewTicker(env.Heartbeat)
+		ticker := time.NewTicker(c.currentNode.Heartbeat)
 		for {
 			select {
 			case <-ticker.C:
@@ -213,6 +254,9 @@ func (c *cluster) checkMemberHeartbeat() {
 					return
 				}
 				check()
+			case <-c.die:
+				ticker.Stop()
+				return
 			}
 		}
 	}()
@@ -232,16 +276,6 @@ func (c *cluster) remoteAddrs() []string {
 	return addrs
 }
 
-func (c *cluster) initMembers(members []*clusterpb.MemberInfo) {
-	c.mu.Lock()
-	for _, info := range members {
-		c.members = append(c.members, &Member{
-			memberInfo: info,
-		})
-	}
-	c.mu.Unlock()
-}
-
 func (c *cluster) addMember(info *clusterpb.MemberInfo) {
 	c.mu.Lock()
 	var found bool
@@ -260,6 +294,38 @@ func (c *cluster) addMember(info *clusterpb.MemberInfo) {
 	c.mu.Unlock()
 }
 
+// updateMember updates the member info of current node and notifies the
+// members if current node is the leader master
+func (c *cluster) updateMember(info *clusterpb.MemberInfo) error {
+	c.mu.Lock()
+	var members []*Member
+	for _, m := range c.members {
+		if m.memberInfo.ServiceAddr == info.ServiceAddr {
+			m.memberInfo = info
+		} else if !m.isMaster {
+			members = append(members, m)
+		}
+	}
+	c.mu.Unlock()
+
+	if !c.isLeader() {
+		return nil
+	}
+	newMember := &clusterpb.NewMemberRequest{MemberInfo: info}
+	for _, m := range members {
+		pool, err := c.rpcClient.getConnPool(m.memberInfo.ServiceAddr)
+		if err != nil {
+			return err
+		}
+		client := clusterpb.NewMemberClient(pool.Get())
+		if _, err := client.NewMember(context.Background(), newMember); err != nil {
+			return err
+		}
+	}
+	c.replicateLater()
+	return nil
+}
+
 func (c *cluster) delMember(addr string) {
 	c.mu.Lock()
 	var index = -1
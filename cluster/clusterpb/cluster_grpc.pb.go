@@ -25,6 +25,8 @@ type MasterClient interface {
 	Register(ctx context.Context, in *RegisterRequest, opts ...grpc.CallOption) (*RegisterResponse, error)
 	Unregister(ctx context.Context, in *UnregisterRequest, opts ...grpc.CallOption) (*UnregisterResponse, error)
 	Heartbeat(ctx context.Context, in *HeartbeatRequest, opts ...grpc.CallOption) (*HeartbeatResponse, error)
+	Vote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*VoteResponse, error)
+	Lease(ctx context.Context, in *LeaseRequest, opts ...grpc.CallOption) (*LeaseResponse, error)
 }
 
 type masterClient struct {
@@ -62,6 +64,24 @@ func (c *masterClient) Heartbeat(ctx context.Context, in *HeartbeatRequest, opts
 	return out, nil
 }
 
+func (c *masterClient) Vote(ctx context.Context, in *VoteRequest, opts ...grpc.CallOption) (*VoteResponse, error) {
+	out := new(VoteResponse)
+	err := c.cc.Invoke(ctx, "/clusterpb.Master/Vote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *masterClient) Lease(ctx context.Context, in *LeaseRequest, opts ...grpc.CallOption) (*LeaseResponse, error) {
+	out := new(LeaseResponse)
+	err := c.cc.Invoke(ctx, "/clusterpb.Master/Lease", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MasterServer is the server API for Master service.
 // All implementations should embed UnimplementedMasterServer
 // for forward compatibility
@@ -69,6 +89,8 @@ type MasterServer interface {
 	Register(context.Context, *RegisterRequest) (*RegisterResponse, error)
 	Unregister(context.Context, *UnregisterRequest) (*UnregisterResponse, error)
 	Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error)
+	Vote(context.Context, *VoteRequest) (*VoteResponse, error)
+	Lease(context.Context, *LeaseRequest) (*LeaseResponse, error)
 }
 
 // UnimplementedMasterServer should be embedded to have forward compatible implementations.
@@ -84,6 +106,12 @@ func (UnimplementedMasterServer) Unregister(context.Context, *UnregisterRequest)
 func (UnimplementedMasterServer) Heartbeat(context.Context, *HeartbeatRequest) (*HeartbeatResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Heartbeat not implemented")
 }
+func (UnimplementedMasterServer) Vote(context.Context, *VoteRequest) (*VoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Vote not implemented")
+}
+func (UnimplementedMasterServer) Lease(context.Context, *LeaseRequest) (*LeaseResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Lease not implemented")
+}
 
 // UnsafeMasterServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to MasterServer will
@@ -150,6 +178,42 @@ func _Master_Heartbeat_Handler(srv interface{}, ctx context.Context, dec func(in
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Master_Vote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterServer).Vote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterpb.Master/Vote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterServer).Vote(ctx, req.(*VoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Master_Lease_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaseRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MasterServer).Lease(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterpb.Master/Lease",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MasterServer).Lease(ctx, req.(*LeaseRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Master_ServiceDesc is the grpc.ServiceDesc for Master service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -169,6 +233,14 @@ var Master_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Heartbeat",
 			Handler:    _Master_Heartbeat_Handler,
 		},
+		{
+			MethodName: "Vote",
+			Handler:    _Master_Vote_Handler,
+		},
+		{
+			MethodName: "Lease",
+			Handler:    _Master_Lease_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "cluster.proto",
@@ -181,11 +253,19 @@ type MemberClient interface {
 	HandleRequest(ctx context.Context, in *RequestMessage, opts ...grpc.CallOption) (*MemberHandleResponse, error)
 	HandleNotify(ctx context.Context, in *NotifyMessage, opts ...grpc.CallOption) (*MemberHandleResponse, error)
 	HandlePush(ctx context.Context, in *PushMessage, opts ...grpc.CallOption) (*MemberHandleResponse, error)
+	HandleMulticast(ctx context.Context, in *MulticastMessage, opts ...grpc.CallOption) (*MemberHandleResponse, error)
+	HandleBatch(ctx context.Context, in *BatchMessage, opts ...grpc.CallOption) (*MemberHandleResponse, error)
+	Stream(ctx context.Context, opts ...grpc.CallOption) (Member_StreamClient, error)
 	HandleResponse(ctx context.Context, in *ResponseMessage, opts ...grpc.CallOption) (*MemberHandleResponse, error)
+	HandleCall(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*CallResponse, error)
 	NewMember(ctx context.Context, in *NewMemberRequest, opts ...grpc.CallOption) (*NewMemberResponse, error)
 	DelMember(ctx context.Context, in *DelMemberRequest, opts ...grpc.CallOption) (*DelMemberResponse, error)
 	SessionClosed(ctx context.Context, in *SessionClosedRequest, opts ...grpc.CallOption) (*SessionClosedResponse, error)
 	CloseSession(ctx context.Context, in *CloseSessionRequest, opts ...grpc.CallOption) (*CloseSessionResponse, error)
+	KickSession(ctx context.Context, in *KickSessionRequest, opts ...grpc.CallOption) (*KickSessionResponse, error)
+	RebindSession(ctx context.Context, in *RebindSessionRequest, opts ...grpc.CallOption) (*RebindSessionResponse, error)
+	RestoreSession(ctx context.Context, in *RestoreSessionRequest, opts ...grpc.CallOption) (*RestoreSessionResponse, error)
+	BindSession(ctx context.Context, in *BindSessionRequest, opts ...grpc.CallOption) (*BindSessionResponse, error)
 }
 
 type memberClient struct {
@@ -223,6 +303,55 @@ func (c *memberClient) HandlePush(ctx context.Context, in *PushMessage, opts ...
 	return out, nil
 }
 
+func (c *memberClient) HandleMulticast(ctx context.Context, in *MulticastMessage, opts ...grpc.CallOption) (*MemberHandleResponse, error) {
+	out := new(MemberHandleResponse)
+	err := c.cc.Invoke(ctx, "/clusterpb.Member/HandleMulticast", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memberClient) HandleBatch(ctx context.Context, in *BatchMessage, opts ...grpc.CallOption) (*MemberHandleResponse, error) {
+	out := new(MemberHandleResponse)
+	err := c.cc.Invoke(ctx, "/clusterpb.Member/HandleBatch", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memberClient) Stream(ctx context.Context, opts ...grpc.CallOption) (Member_StreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Member_ServiceDesc.Streams[0], "/clusterpb.Member/Stream", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &memberStreamClient{stream}
+	return x, nil
+}
+
+type Member_StreamClient interface {
+	Send(*StreamMessage) error
+	Recv() (*StreamMessage, error)
+	grpc.ClientStream
+}
+
+type memberStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *memberStreamClient) Send(m *StreamMessage) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *memberStreamClient) Recv() (*StreamMessage, error) {
+	m := new(StreamMessage)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *memberClient) HandleResponse(ctx context.Context, in *ResponseMessage, opts ...grpc.CallOption) (*MemberHandleResponse, error) {
 	out := new(MemberHandleResponse)
 	err := c.cc.Invoke(ctx, "/clusterpb.Member/HandleResponse", in, out, opts...)
@@ -232,6 +361,15 @@ func (c *memberClient) HandleResponse(ctx context.Context, in *ResponseMessage,
 	return out, nil
 }
 
+func (c *memberClient) HandleCall(ctx context.Context, in *CallRequest, opts ...grpc.CallOption) (*CallResponse, error) {
+	out := new(CallResponse)
+	err := c.cc.Invoke(ctx, "/clusterpb.Member/HandleCall", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *memberClient) NewMember(ctx context.Context, in *NewMemberRequest, opts ...grpc.CallOption) (*NewMemberResponse, error) {
 	out := new(NewMemberResponse)
 	err := c.cc.Invoke(ctx, "/clusterpb.Member/NewMember", in, out, opts...)
@@ -268,6 +406,42 @@ func (c *memberClient) CloseSession(ctx context.Context, in *CloseSessionRequest
 	return out, nil
 }
 
+func (c *memberClient) KickSession(ctx context.Context, in *KickSessionRequest, opts ...grpc.CallOption) (*KickSessionResponse, error) {
+	out := new(KickSessionResponse)
+	err := c.cc.Invoke(ctx, "/clusterpb.Member/KickSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memberClient) RebindSession(ctx context.Context, in *RebindSessionRequest, opts ...grpc.CallOption) (*RebindSessionResponse, error) {
+	out := new(RebindSessionResponse)
+	err := c.cc.Invoke(ctx, "/clusterpb.Member/RebindSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memberClient) RestoreSession(ctx context.Context, in *RestoreSessionRequest, opts ...grpc.CallOption) (*RestoreSessionResponse, error) {
+	out := new(RestoreSessionResponse)
+	err := c.cc.Invoke(ctx, "/clusterpb.Member/RestoreSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *memberClient) BindSession(ctx context.Context, in *BindSessionRequest, opts ...grpc.CallOption) (*BindSessionResponse, error) {
+	out := new(BindSessionResponse)
+	err := c.cc.Invoke(ctx, "/clusterpb.Member/BindSession", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MemberServer is the server API for Member service.
 // All implementations should embed UnimplementedMemberServer
 // for forward compatibility
@@ -275,11 +449,19 @@ type MemberServer interface {
 	HandleRequest(context.Context, *RequestMessage) (*MemberHandleResponse, error)
 	HandleNotify(context.Context, *NotifyMessage) (*MemberHandleResponse, error)
 	HandlePush(context.Context, *PushMessage) (*MemberHandleResponse, error)
+	HandleMulticast(context.Context, *MulticastMessage) (*MemberHandleResponse, error)
+	HandleBatch(context.Context, *BatchMessage) (*MemberHandleResponse, error)
+	Stream(Member_StreamServer) error
 	HandleResponse(context.Context, *ResponseMessage) (*MemberHandleResponse, error)
+	HandleCall(context.Context, *CallRequest) (*CallResponse, error)
 	NewMember(context.Context, *NewMemberRequest) (*NewMemberResponse, error)
 	DelMember(context.Context, *DelMemberRequest) (*DelMemberResponse, error)
 	SessionClosed(context.Context, *SessionClosedRequest) (*SessionClosedResponse, error)
 	CloseSession(context.Context, *CloseSessionRequest) (*CloseSessionResponse, error)
+	KickSession(context.Context, *KickSessionRequest) (*KickSessionResponse, error)
+	RebindSession(context.Context, *RebindSessionRequest) (*RebindSessionResponse, error)
+	RestoreSession(context.Context, *RestoreSessionRequest) (*RestoreSessionResponse, error)
+	BindSession(context.Context, *BindSessionRequest) (*BindSessionResponse, error)
 }
 
 // UnimplementedMemberServer should be embedded to have forward compatible implementations.
@@ -295,9 +477,21 @@ func (UnimplementedMemberServer) HandleNotify(context.Context, *NotifyMessage) (
 func (UnimplementedMemberServer) HandlePush(context.Context, *PushMessage) (*MemberHandleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HandlePush not implemented")
 }
+func (UnimplementedMemberServer) HandleMulticast(context.Context, *MulticastMessage) (*MemberHandleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HandleMulticast not implemented")
+}
+func (UnimplementedMemberServer) HandleBatch(context.Context, *BatchMessage) (*MemberHandleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HandleBatch not implemented")
+}
+func (UnimplementedMemberServer) Stream(Member_StreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method Stream not implemented")
+}
 func (UnimplementedMemberServer) HandleResponse(context.Context, *ResponseMessage) (*MemberHandleResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method HandleResponse not implemented")
 }
+func (UnimplementedMemberServer) HandleCall(context.Context, *CallRequest) (*CallResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HandleCall not implemented")
+}
 func (UnimplementedMemberServer) NewMember(context.Context, *NewMemberRequest) (*NewMemberResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method NewMember not implemented")
 }
@@ -310,6 +504,18 @@ func (UnimplementedMemberServer) SessionClosed(context.Context, *SessionClosedRe
 func (UnimplementedMemberServer) CloseSession(context.Context, *CloseSessionRequest) (*CloseSessionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method CloseSession not implemented")
 }
+func (UnimplementedMemberServer) KickSession(context.Context, *KickSessionRequest) (*KickSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KickSession not implemented")
+}
+func (UnimplementedMemberServer) RebindSession(context.Context, *RebindSessionRequest) (*RebindSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RebindSession not implemented")
+}
+func (UnimplementedMemberServer) RestoreSession(context.Context, *RestoreSessionRequest) (*RestoreSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RestoreSession not implemented")
+}
+func (UnimplementedMemberServer) BindSession(context.Context, *BindSessionRequest) (*BindSessionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BindSession not implemented")
+}
 
 // UnsafeMemberServer may be embedded to opt out of forward compatibility for this service.
 // Use of this interface is not recommended, as added methods to MemberServer will
@@ -376,6 +582,68 @@ func _Member_HandlePush_Handler(srv interface{}, ctx context.Context, dec func(i
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Member_HandleMulticast_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MulticastMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemberServer).HandleMulticast(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterpb.Member/HandleMulticast",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemberServer).HandleMulticast(ctx, req.(*MulticastMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Member_HandleBatch_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BatchMessage)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemberServer).HandleBatch(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterpb.Member/HandleBatch",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemberServer).HandleBatch(ctx, req.(*BatchMessage))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Member_Stream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(MemberServer).Stream(&memberStreamServer{stream})
+}
+
+type Member_StreamServer interface {
+	Send(*StreamMessage) error
+	Recv() (*StreamMessage, error)
+	grpc.ServerStream
+}
+
+type memberStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *memberStreamServer) Send(m *StreamMessage) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *memberStreamServer) Recv() (*StreamMessage, error) {
+	m := new(StreamMessage)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func _Member_HandleResponse_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ResponseMessage)
 	if err := dec(in); err != nil {
@@ -394,6 +662,24 @@ func _Member_HandleResponse_Handler(srv interface{}, ctx context.Context, dec fu
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Member_HandleCall_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CallRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemberServer).HandleCall(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterpb.Member/HandleCall",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemberServer).HandleCall(ctx, req.(*CallRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _Member_NewMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(NewMemberRequest)
 	if err := dec(in); err != nil {
@@ -466,6 +752,78 @@ func _Member_CloseSession_Handler(srv interface{}, ctx context.Context, dec func
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Member_KickSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KickSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemberServer).KickSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterpb.Member/KickSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemberServer).KickSession(ctx, req.(*KickSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Member_RebindSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RebindSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemberServer).RebindSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterpb.Member/RebindSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemberServer).RebindSession(ctx, req.(*RebindSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Member_RestoreSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemberServer).RestoreSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterpb.Member/RestoreSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemberServer).RestoreSession(ctx, req.(*RestoreSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Member_BindSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BindSessionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MemberServer).BindSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/clusterpb.Member/BindSession",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MemberServer).BindSession(ctx, req.(*BindSessionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Member_ServiceDesc is the grpc.ServiceDesc for Member service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -485,10 +843,22 @@ var Member_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "HandlePush",
 			Handler:    _Member_HandlePush_Handler,
 		},
+		{
+			MethodName: "HandleMulticast",
+			Handler:    _Member_HandleMulticast_Handler,
+		},
+		{
+			MethodName: "HandleBatch",
+			Handler:    _Member_HandleBatch_Handler,
+		},
 		{
 			MethodName: "HandleResponse",
 			Handler:    _Member_HandleResponse_Handler,
 		},
+		{
+			MethodName: "HandleCall",
+			Handler:    _Member_HandleCall_Handler,
+		},
 		{
 			MethodName: "NewMember",
 			Handler:    _Member_NewMember_Handler,
@@ -505,7 +875,30 @@ var Member_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "CloseSession",
 			Handler:    _Member_CloseSession_Handler,
 		},
+		{
+			MethodName: "KickSession",
+			Handler:    _Member_KickSession_Handler,
+		},
+		{
+			MethodName: "RebindSession",
+			Handler:    _Member_RebindSession_Handler,
+		},
+		{
+			MethodName: "RestoreSession",
+			Handler:    _Member_RestoreSession_Handler,
+		},
+		{
+			MethodName: "BindSession",
+			Handler:    _Member_BindSession_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Stream",
+			Handler:       _Member_Stream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "cluster.proto",
 }
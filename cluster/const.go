@@ -25,5 +25,6 @@ const (
 	statusStart
 	statusHandshake
 	statusWorking
+	statusParked
 	statusClosed
 )
@@ -0,0 +1,81 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/lonng/nano/session"
+)
+
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	gateAddrKey
+	nodeKey
+)
+
+// RequestID returns the message id of the request which the handler context
+// is created for, zero for a notify
+func RequestID(ctx context.Context) uint64 {
+	id, _ := ctx.Value(requestIDKey).(uint64)
+	return id
+}
+
+// GateAddr returns the service address of the gate which the request
+// originates from
+func GateAddr(ctx context.Context) string {
+	addr, _ := ctx.Value(gateAddrKey).(string)
+	return addr
+}
+
+// sessionContext returns a context which is canceled once the session closed
+func sessionContext(s *session.Session) context.Context {
+	switch v := s.NetworkEntity().(type) {
+	case *agent:
+		return v.ctx
+	case *acceptor:
+		return v.ctx
+	}
+	return context.Background()
+}
+
+// handlerContext creates the context passed to a context-aware handler, which
+// inherits the deadline of parent and is canceled once the session closed.
+// Only the deadline and the session close cross the nodes: a remote handler
+// runs after HandleRequest returned, so canceling parent does not cancel it
+func (h *LocalHandler) handlerContext(parent context.Context, s *session.Session, mid uint64) (context.Context, context.CancelFunc) {
+	var ctx context.Context
+	var cancel context.CancelFunc
+	if deadline, ok := parent.Deadline(); ok {
+		ctx, cancel = context.WithDeadline(sessionContext(s), deadline)
+	} else if timeout := h.currentNode.RequestTimeout; timeout > 0 {
+		ctx, cancel = context.WithTimeout(sessionContext(s), timeout)
+	} else {
+		ctx, cancel = context.WithCancel(sessionContext(s))
+	}
+	gateAddr, _ := h.origin(s)
+	ctx = context.WithValue(ctx, requestIDKey, mid)
+	ctx = context.WithValue(ctx, gateAddrKey, gateAddr)
+	ctx = context.WithValue(ctx, nodeKey, h.currentNode)
+	return ctx, cancel
+}
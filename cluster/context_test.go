@@ -0,0 +1,96 @@
+package cluster_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/lonng/nano/benchmark/testdata"
+	"github.com/lonng/nano/cluster"
+	"github.com/lonng/nano/component"
+	"github.com/lonng/nano/internal/message"
+	"github.com/lonng/nano/internal/packet"
+	"github.com/lonng/nano/serialize/protobuf"
+	"github.com/lonng/nano/session"
+	. "github.com/pingcap/check"
+)
+
+var (
+	waitStarted = make(chan struct{}, 1)
+	waitErr     = make(chan error, 1)
+)
+
+func (c *GameComponent) Echo(ctx context.Context, s *session.Session, ping *testdata.Ping) (*testdata.Pong, error) {
+	_, deadline := ctx.Deadline()
+	content := fmt.Sprintf("%s %d %s %v", ping.Content, cluster.RequestID(ctx), cluster.GateAddr(ctx), deadline)
+	return &testdata.Pong{Content: content}, nil
+}
+
+func (c *GameComponent) Wait(ctx context.Context, s *session.Session, ping *testdata.Ping) (*testdata.Pong, error) {
+	select {
+	case waitStarted <- struct{}{}:
+	default:
+	}
+	<-ctx.Done()
+	waitErr <- ctx.Err()
+	return nil, ctx.Err()
+}
+
+func (s *nodeSuite) TestContextHandler(c *C) {
+	masterNode := startNode(c, cluster.Options{IsMaster: true})
+	defer masterNode.Shutdown()
+
+	gateComps := &component.Components{}
+	gateComps.Register(&GateComponent{})
+	gateNode := startNode(c, cluster.Options{
+		AdvertiseAddr:  masterNode.ServiceAddr,
+		ClientAddr:     "127.0.0.1:0",
+		Components:     gateComps,
+		RequestTimeout: 300 * time.Millisecond,
+	})
+	defer gateNode.Shutdown()
+
+	gameComps := &component.Components{}
+	gameComps.Register(&GameComponent{})
+	gameNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		Components:    gameComps,
+	})
+	defer gameNode.Shutdown()
+
+	send := func(client *rawClient, id uint64, route string) {
+		data, err := protobuf.NewSerializer().Marshal(&testdata.Ping{Content: "ping"})
+		c.Assert(err, IsNil)
+		m, err := message.Encode(&message.Message{Type: message.Request, ID: id, Route: route, Data: data})
+		c.Assert(err, IsNil)
+		client.write(c, packet.Data, m)
+	}
+
+	// The returned value is responded, the context carries the request metadata
+	client, _ := dialRaw(c, gateNode.ClientAddr, `{"sys":{}}`)
+	defer client.conn.Close()
+	pong := client.request(c, 1, "GameComponent.Echo", &testdata.Ping{Content: "ping"})
+	c.Assert(pong.Content, Equals, fmt.Sprintf("ping 1 %s true", gateNode.ServiceAddr))
+
+	// The deadline is propagated to the backend
+	send(client, 2, "GameComponent.Wait")
+	<-waitStarted
+	c.Assert(<-waitErr, Equals, context.DeadlineExceeded)
+	p := client.read(c)
+	resp, err := message.Decode(p.Data)
+	c.Assert(err, IsNil)
+	c.Assert(resp.ID, Equals, uint64(2))
+	c.Assert(resp.Err, IsTrue)
+
+	// The context is canceled once the session closed
+	other, _ := dialRaw(c, gateNode.ClientAddr, `{"sys":{}}`)
+	send(other, 1, "GameComponent.Wait")
+	<-waitStarted
+	other.conn.Close()
+	select {
+	case err := <-waitErr:
+		c.Assert(err, Equals, context.Canceled)
+	case <-time.After(time.Second):
+		c.Fatal("context is not canceled after session closed")
+	}
+}
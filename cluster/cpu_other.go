@@ -18,8 +18,14 @@
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
 // SOFTWARE.
 
-package runtime
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
 
-import "github.com/lonng/nano/cluster"
+package cluster
 
-var CurrentNode *cluster.Node
+import "time"
+
+// cpuTime returns zero on the platforms which the CPU time is not supported
+func cpuTime() time.Duration {
+	return 0
+}
@@ -0,0 +1,117 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cluster
+
+import (
+	"context"
+	"sync"
+
+	"github.com/lonng/nano/cluster/clusterpb"
+)
+
+// MemberEventType represents the type of membership change
+type MemberEventType int
+
+const (
+	// MemberAdded indicates a member joined the cluster
+	MemberAdded MemberEventType = iota
+	// MemberRemoved indicates a member left the cluster
+	MemberRemoved
+)
+
+// MemberEvent represents a membership change of the cluster
+type MemberEvent struct {
+	Type   MemberEventType
+	Member *clusterpb.MemberInfo
+}
+
+// Discovery is the membership backend of a nano cluster. The master node is the
+// default implementation, and an external registry can be plugged in via a custom
+// implementation, in which case the cluster runs without a master node.
+type Discovery interface {
+	// Register registers the current member into the cluster and returns the
+	// members already in the cluster
+	Register(ctx context.Context, member *clusterpb.MemberInfo) ([]*clusterpb.MemberInfo, error)
+	// Deregister removes the current member from the cluster
+	Deregister(ctx context.Context, member *clusterpb.MemberInfo) error
+	// Watch calls handler with the membership changes until ctx is done
+	Watch(ctx context.Context, handler func(MemberEvent)) error
+}
+
+// masterDiscovery discovers members through the master nodes, the membership
+// changes are pushed by the leader master via the Member service
+type masterDiscovery struct {
+	node *Node
+
+	mu      sync.RWMutex
+	handler func(MemberEvent)
+}
+
+func newMasterDiscovery(node *Node) *masterDiscovery {
+	return &masterDiscovery{node: node}
+}
+
+func (d *masterDiscovery) Register(ctx context.Context, member *clusterpb.MemberInfo) ([]*clusterpb.MemberInfo, error) {
+	var members []*clusterpb.MemberInfo
+	err := d.node.callMaster(func(client clusterpb.MasterClient) error {
+		resp, err := client.Register(ctx, &clusterpb.RegisterRequest{MemberInfo: member})
+		if err != nil {
+			return err
+		}
+		members = resp.Members
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	d.node.once.Do(d.node.keepalive)
+	return members, nil
+}
+
+func (d *masterDiscovery) Deregister(ctx context.Context, member *clusterpb.MemberInfo) error {
+	return d.node.callMaster(func(client clusterpb.MasterClient) error {
+		_, err := client.Unregister(ctx, &clusterpb.UnregisterRequest{ServiceAddr: member.ServiceAddr})
+		return err
+	})
+}
+
+func (d *masterDiscovery) Watch(ctx context.Context, handler func(MemberEvent)) error {
+	d.mu.Lock()
+	d.handler = handler
+	d.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		d.mu.Lock()
+		d.handler = nil
+		d.mu.Unlock()
+	}()
+	return nil
+}
+
+func (d *masterDiscovery) notify(event MemberEvent) {
+	d.mu.RLock()
+	handler := d.handler
+	d.mu.RUnlock()
+	if handler != nil {
+		handler(event)
+	}
+}
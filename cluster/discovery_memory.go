@@ -0,0 +1,107 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cluster
+
+import (
+	"context"
+	"sync"
+
+	"github.com/lonng/nano/cluster/clusterpb"
+)
+
+// MemoryDiscovery is a Discovery which keeps the members in memory, the nodes
+// in the same process share the membership through it, e.g. in tests
+type MemoryDiscovery struct {
+	mu       sync.Mutex
+	seq      int
+	members  []*clusterpb.MemberInfo
+	watchers map[int]func(MemberEvent)
+}
+
+// NewMemoryDiscovery returns a new MemoryDiscovery
+func NewMemoryDiscovery() *MemoryDiscovery {
+	return &MemoryDiscovery{watchers: map[int]func(MemberEvent){}}
+}
+
+// Register implements the Discovery interface
+func (d *MemoryDiscovery) Register(_ context.Context, member *clusterpb.MemberInfo) ([]*clusterpb.MemberInfo, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.remove(member.ServiceAddr)
+	members := append([]*clusterpb.MemberInfo{}, d.members...)
+	d.members = append(d.members, member)
+	d.notify(MemberEvent{Type: MemberAdded, Member: member})
+	return members, nil
+}
+
+// Deregister implements the Discovery interface
+func (d *MemoryDiscovery) Deregister(_ context.Context, member *clusterpb.MemberInfo) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.remove(member.ServiceAddr) {
+		d.notify(MemberEvent{Type: MemberRemoved, Member: member})
+	}
+	return nil
+}
+
+// Watch implements the Discovery interface
+func (d *MemoryDiscovery) Watch(ctx context.Context, handler func(MemberEvent)) error {
+	d.mu.Lock()
+	d.seq++
+	id := d.seq
+	d.watchers[id] = handler
+	d.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		d.mu.Lock()
+		delete(d.watchers, id)
+		d.mu.Unlock()
+	}()
+	return nil
+}
+
+// Members returns the registered members
+func (d *MemoryDiscovery) Members() []*clusterpb.MemberInfo {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return append([]*clusterpb.MemberInfo{}, d.members...)
+}
+
+// remove must be called with lock held
+func (d *MemoryDiscovery) remove(addr string) bool {
+	for i, m := range d.members {
+		if m.ServiceAddr == addr {
+			d.members = append(d.members[:i], d.members[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// notify must be called with lock held, which keeps the events in order
+func (d *MemoryDiscovery) notify(event MemberEvent) {
+	for _, handler := range d.watchers {
+		handler(event)
+	}
+}
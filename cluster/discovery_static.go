@@ -0,0 +1,178 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cluster
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lonng/nano/cluster/clusterpb"
+	"github.com/lonng/nano/internal/log"
+	"google.golang.org/protobuf/proto"
+)
+
+const defaultResolveInterval = 10 * time.Second
+
+// Resolver resolves all members of the cluster from a source maintained outside nano
+type Resolver func(ctx context.Context) ([]*clusterpb.MemberInfo, error)
+
+// StaticDiscovery is a Discovery which polls the members from a resolver, the
+// members are maintained by the resolver source, so register and deregister
+// do nothing but return the current members
+type StaticDiscovery struct {
+	resolve  Resolver
+	interval time.Duration
+
+	mu      sync.Mutex
+	members map[string]*clusterpb.MemberInfo
+}
+
+// NewStaticDiscovery returns a StaticDiscovery which resolves the members every interval
+func NewStaticDiscovery(resolve Resolver, interval time.Duration) *StaticDiscovery {
+	if interval <= 0 {
+		interval = defaultResolveInterval
+	}
+	return &StaticDiscovery{
+		resolve:  resolve,
+		interval: interval,
+		members:  map[string]*clusterpb.MemberInfo{},
+	}
+}
+
+// NewFileDiscovery returns a StaticDiscovery which reads the members from a JSON
+// file, e.g. [{"label":"game","serviceAddr":"10.0.0.1:4450","services":["Room"]}]
+func NewFileDiscovery(path string, interval time.Duration) *StaticDiscovery {
+	return NewStaticDiscovery(func(context.Context) ([]*clusterpb.MemberInfo, error) {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var members []*clusterpb.MemberInfo
+		if err := json.Unmarshal(data, &members); err != nil {
+			return nil, err
+		}
+		return members, nil
+	}, interval)
+}
+
+// NewSRVDiscovery returns a StaticDiscovery which looks up the DNS SRV records
+// _service._tcp.domain for each service, the targets are the service addresses
+// of the members which provide the service
+func NewSRVDiscovery(domain string, services []string, interval time.Duration) *StaticDiscovery {
+	return NewStaticDiscovery(func(ctx context.Context) ([]*clusterpb.MemberInfo, error) {
+		members := map[string]*clusterpb.MemberInfo{}
+		for _, service := range services {
+			_, records, err := net.DefaultResolver.LookupSRV(ctx, service, "tcp", domain)
+			if err != nil {
+				return nil, err
+			}
+			for _, r := range records {
+				addr := net.JoinHostPort(strings.TrimSuffix(r.Target, "."), strconv.Itoa(int(r.Port)))
+				m, found := members[addr]
+				if !found {
+					m = &clusterpb.MemberInfo{ServiceAddr: addr}
+					members[addr] = m
+				}
+				m.Services = append(m.Services, service)
+			}
+		}
+		var result []*clusterpb.MemberInfo
+		for _, m := range members {
+			result = append(result, m)
+		}
+		sort.Slice(result, func(i, j int) bool {
+			return result[i].ServiceAddr < result[j].ServiceAddr
+		})
+		return result, nil
+	}, interval)
+}
+
+// Register implements the Discovery interface
+func (d *StaticDiscovery) Register(ctx context.Context, _ *clusterpb.MemberInfo) ([]*clusterpb.MemberInfo, error) {
+	members, err := d.resolve(ctx)
+	if err != nil {
+		return nil, err
+	}
+	d.mu.Lock()
+	for _, m := range members {
+		d.members[m.ServiceAddr] = m
+	}
+	d.mu.Unlock()
+	return members, nil
+}
+
+// Deregister implements the Discovery interface
+func (d *StaticDiscovery) Deregister(context.Context, *clusterpb.MemberInfo) error {
+	return nil
+}
+
+// Watch implements the Discovery interface
+func (d *StaticDiscovery) Watch(ctx context.Context, handler func(MemberEvent)) error {
+	go func() {
+		ticker := time.NewTicker(d.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				members, err := d.resolve(ctx)
+				if err != nil {
+					log.Println("Resolve cluster members failed", err)
+					continue
+				}
+				for _, event := range d.update(members) {
+					handler(event)
+				}
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+// update replaces the members and returns the changes
+func (d *StaticDiscovery) update(members []*clusterpb.MemberInfo) []MemberEvent {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	var events []MemberEvent
+	latest := map[string]*clusterpb.MemberInfo{}
+	for _, m := range members {
+		latest[m.ServiceAddr] = m
+		if old, found := d.members[m.ServiceAddr]; !found || !proto.Equal(old, m) {
+			events = append(events, MemberEvent{Type: MemberAdded, Member: m})
+		}
+	}
+	for addr, m := range d.members {
+		if _, found := latest[addr]; !found {
+			events = append(events, MemberEvent{Type: MemberRemoved, Member: m})
+		}
+	}
+	d.members = latest
+	return events
+}
@@ -0,0 +1,89 @@
+package cluster_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/lonng/nano/benchmark/testdata"
+	"github.com/lonng/nano/cluster"
+	"github.com/lonng/nano/component"
+	. "github.com/pingcap/check"
+)
+
+func (s *nodeSuite) TestMemoryDiscovery(c *C) {
+	discovery := cluster.NewMemoryDiscovery()
+
+	gameComps := &component.Components{}
+	gameComps.Register(&GameComponent{})
+	gameNode := startNode(c, cluster.Options{
+		Discovery:  discovery,
+		Components: gameComps,
+	})
+
+	gateNode := startNode(c, cluster.Options{Discovery: discovery})
+	defer gateNode.Shutdown()
+	c.Assert(discovery.Members(), HasLen, 2)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	pong := &testdata.Pong{}
+	c.Assert(gateNode.Call(ctx, "GameComponent.Test4", &testdata.Ping{Content: "ping"}, pong), IsNil)
+	c.Assert(pong.Content, Equals, "ping")
+
+	gameNode.Shutdown()
+	c.Assert(discovery.Members(), HasLen, 1)
+	c.Assert(gateNode.Call(ctx, "GameComponent.Test4", &testdata.Ping{}, nil), Equals, cluster.ErrServiceNotFound)
+}
+
+func (s *nodeSuite) TestFileDiscovery(c *C) {
+	dir, err := ioutil.TempDir("", "nano")
+	c.Assert(err, IsNil)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "members.json")
+	addrs := freeAddrs(c, 2)
+	members := fmt.Sprintf(`[
+		{"label": "game", "serviceAddr": %q, "services": ["GameComponent"]},
+		{"label": "gate", "serviceAddr": %q}
+	]`, addrs[0], addrs[1])
+	c.Assert(ioutil.WriteFile(path, []byte(members), 0644), IsNil)
+
+	gameComps := &component.Components{}
+	gameComps.Register(&GameComponent{})
+	gameNode := &cluster.Node{
+		Options: cluster.Options{
+			Discovery:  cluster.NewFileDiscovery(path, 50*time.Millisecond),
+			Components: gameComps,
+		},
+		ServiceAddr: addrs[0],
+	}
+	c.Assert(gameNode.Startup(), IsNil)
+	defer gameNode.Shutdown()
+
+	gateNode := &cluster.Node{
+		Options: cluster.Options{
+			Discovery:  cluster.NewFileDiscovery(path, 50*time.Millisecond),
+			Components: &component.Components{},
+		},
+		ServiceAddr: addrs[1],
+	}
+	c.Assert(gateNode.Startup(), IsNil)
+	defer gateNode.Shutdown()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	pong := &testdata.Pong{}
+	c.Assert(gateNode.Call(ctx, "GameComponent.Test4", &testdata.Ping{Content: "ping"}, pong), IsNil)
+	c.Assert(pong.Content, Equals, "ping")
+
+	// Members removed from the file are removed from the cluster
+	members = fmt.Sprintf(`[{"label": "gate", "serviceAddr": %q}]`, addrs[1])
+	c.Assert(ioutil.WriteFile(path, []byte(members), 0644), IsNil)
+	waitFor(c, time.Second, func() bool {
+		return gateNode.Call(ctx, "GameComponent.Test4", &testdata.Ping{}, nil) == cluster.ErrServiceNotFound
+	})
+}
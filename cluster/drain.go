@@ -0,0 +1,188 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/lonng/nano/cluster/clusterpb"
+	"github.com/lonng/nano/internal/log"
+	"github.com/lonng/nano/session"
+)
+
+// drainInterval is the interval of checking the sessions left while draining
+const drainInterval = 100 * time.Millisecond
+
+// Drain drains current node before shutting it down, e.g. in a rolling deploy.
+// The node is marked as draining in the cluster so that no new sessions will be
+// bound to it, and stops accepting the clients. The sessions forwarded from the
+// gates are handed off via the SessionHandoff callback and the gates are asked
+// to rebind them to the other members, the callback could migrate the session
+// to a specified member via MigrateSession. The handed off sessions are closed
+// once the gates rebind them, otherwise they are handed off again until the
+// gates rebind them, and the messages arriving meanwhile are forwarded
+// again by the gates, or responded with an error through the forward streams.
+// Drain returns once all sessions are gone or ctx is done.
+//
+// The draining mark cannot be advertised by the StaticDiscovery, whose members
+// are maintained outside nano.
+func (n *Node) Drain(ctx context.Context) error {
+	if atomic.CompareAndSwapInt32(&n.draining, 0, 1) {
+		n.stopAccept()
+		if err := n.advertise(ctx); err != nil {
+			return err
+		}
+	}
+
+	ticker := time.NewTicker(drainInterval)
+	defer ticker.Stop()
+	for {
+		left, err := n.handoff(ctx)
+		if err != nil {
+			return err
+		}
+		if left == 0 {
+			return nil
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// IsDraining returns whether current node is draining
+func (n *Node) IsDraining() bool {
+	return atomic.LoadInt32(&n.draining) == 1
+}
+
+// advertise updates the member info of current node in the cluster
+func (n *Node) advertise(ctx context.Context) error {
+	if n.IsMaster && n.cluster != nil {
+		return n.cluster.updateMember(n.memberInfo())
+	}
+	if n.discovery == nil {
+		return nil
+	}
+	_, err := n.discovery.Register(ctx, n.memberInfo())
+	return err
+}
+
+// handoff hands off the sessions forwarded from the gates and asks the gates
+// to rebind them, returns the number of the sessions left on current node. The
+// handed off sessions are kept until the gates rebind them, then closed, and
+// the ones failed to be rebound are handed off again in the next round.
+func (n *Node) handoff(ctx context.Context) (int, error) {
+	var forwarded []*session.Session
+	var moves []chan struct{}
+	n.mu.Lock()
+	for sid, s := range n.sessions {
+		if _, ok := s.NetworkEntity().(*acceptor); !ok {
+			continue
+		}
+		if _, moving := n.moving[sid]; !moving {
+			moved := make(chan struct{})
+			n.moving[sid] = moved
+			forwarded = append(forwarded, s)
+			moves = append(moves, moved)
+		}
+	}
+	left := len(n.sessions)
+	n.mu.Unlock()
+
+	if len(forwarded) == 0 {
+		return left, nil
+	}
+
+	// Hand off the sessions in the scheduler, which the handlers mutate the
+	// session state in
+	done := make(chan struct{})
+	n.Scheduler.PushTask(func() {
+		defer close(done)
+		for i, s := range forwarded {
+			if n.SessionHandoff != nil {
+				if err := n.SessionHandoff(s); err != nil {
+					log.Println(fmt.Sprintf("Hand off session %d failed: %v", s.ID(), err))
+				}
+			}
+			ac := s.NetworkEntity().(*acceptor)
+			if ac.migratedTo() != "" {
+				// Migrated by the callback, which forgot the session already
+				n.endMove(ac.sid, s, moves[i], true)
+				continue
+			}
+			err := ac.rebind(n.ServiceAddr, "")
+			if err != nil {
+				// The session is still served and handed off again later
+				log.Println(fmt.Sprintf("Rebind session %d failed: %v", s.ID(), err))
+				n.metrics.rebindFailures.Inc()
+			}
+			if n.endMove(ac.sid, s, moves[i], err == nil) {
+				ac.cancel()
+				n.closeSession(s)
+			}
+		}
+	})
+	select {
+	case <-done:
+		n.mu.RLock()
+		left = len(n.sessions)
+		n.mu.RUnlock()
+		return left, nil
+	case <-ctx.Done():
+		return left, ctx.Err()
+	}
+}
+
+// RebindSession implements the MemberServer interface
+func (n *Node) RebindSession(_ context.Context, req *clusterpb.RebindSessionRequest) (*clusterpb.RebindSessionResponse, error) {
+	s := n.findSession(req.SessionId)
+	if s == nil {
+		return &clusterpb.RebindSessionResponse{}, nil
+	}
+	if req.Target == "" {
+		s.Router().Unbind(req.ServiceAddr)
+		return &clusterpb.RebindSessionResponse{}, nil
+	}
+	for _, service := range s.Router().Services(req.ServiceAddr) {
+		if n.handler.provides(service, req.Target) {
+			s.Router().Bind(service, req.Target)
+		} else {
+			s.Router().Delete(service)
+		}
+	}
+	return &clusterpb.RebindSessionResponse{}, nil
+}
+
+// serving returns the members which are not draining
+func serving(members []*clusterpb.MemberInfo) []*clusterpb.MemberInfo {
+	var result []*clusterpb.MemberInfo
+	for _, m := range members {
+		if !m.Draining {
+			result = append(result, m)
+		}
+	}
+	return result
+}
@@ -0,0 +1,126 @@
+package cluster_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/lonng/nano/benchmark/testdata"
+	"github.com/lonng/nano/client"
+	"github.com/lonng/nano/cluster"
+	"github.com/lonng/nano/component"
+	"github.com/lonng/nano/session"
+	. "github.com/pingcap/check"
+)
+
+func (c *GameComponent) FailRebind(s *session.Session, _ *testdata.Ping) error {
+	cluster.FailRebind(s, 1, errors.New("rebind failed"))
+	return s.Response(&testdata.Pong{Content: "failing"})
+}
+
+func (s *nodeSuite) TestDrain(c *C) {
+	masterNode := startNode(c, cluster.Options{IsMaster: true})
+	defer masterNode.Shutdown()
+
+	handoff := make(chan int64, 1)
+	release := make(chan struct{})
+	closed := make(chan int64, 1)
+	var games []*cluster.Node
+	for i := 0; i < 2; i++ {
+		lifetime := session.NewLifetime()
+		lifetime.OnClosed(func(s *session.Session) {
+			select {
+			case closed <- s.ID():
+			default:
+			}
+		})
+		gameComps := &component.Components{}
+		gameComps.Register(&GameComponent{})
+		gameNode := startNode(c, cluster.Options{
+			AdvertiseAddr: masterNode.ServiceAddr,
+			Components:    gameComps,
+			Lifetime:      lifetime,
+			SessionHandoff: func(s *session.Session) error {
+				handoff <- s.ID()
+				<-release
+				return nil
+			},
+		})
+		defer gameNode.Shutdown()
+		games = append(games, gameNode)
+	}
+
+	gateNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		ClientAddr:    "127.0.0.1:0",
+	})
+	defer gateNode.Shutdown()
+
+	echo := func(cli *client.Client) {
+		pong := &testdata.Pong{}
+		c.Assert(cli.Request(context.Background(), "GameComponent.Echo", &testdata.Ping{Content: "ping"}, pong), IsNil)
+	}
+	cli, err := client.Dial(context.Background(), gateNode.ClientAddr)
+	c.Assert(err, IsNil)
+	defer cli.Close()
+	echo(cli)
+
+	drained, other := games[0], games[1]
+	if drained.Load().Sessions == 0 {
+		drained, other = other, drained
+	}
+	c.Assert(drained.Load().Sessions, Equals, int64(1))
+	pong := &testdata.Pong{}
+	c.Assert(cli.Request(context.Background(), "GameComponent.FailRebind", &testdata.Ping{}, pong), IsNil)
+
+	// The session is kept until the gate rebinds it to the other member, which
+	// fails in the first round, the message arriving meanwhile is forwarded
+	// again to the other member or served by current member
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	drainErr := make(chan error, 1)
+	go func() { drainErr <- drained.Drain(ctx) }()
+	id := <-handoff
+	c.Assert(drained.Load().Sessions, Equals, int64(1))
+	echoed := make(chan struct{})
+	go func() {
+		defer close(echoed)
+		echo(cli)
+	}()
+	close(release)
+	c.Assert(<-drainErr, IsNil)
+	c.Assert(drained.IsDraining(), IsTrue)
+	c.Assert(drained.Load().Sessions, Equals, int64(0))
+	buf := &bytes.Buffer{}
+	c.Assert(drained.Metrics().Write(buf), IsNil)
+	c.Assert(buf.String(), Matches, `(?s).*\nnano_rebind_failures_total 1\n.*`)
+	select {
+	case closedID := <-closed:
+		c.Assert(closedID, Equals, id)
+	case <-time.After(time.Second):
+		c.Fatal("handed off session not closed")
+	}
+
+	<-echoed
+	echo(cli)
+	c.Assert(drained.Load().Sessions, Equals, int64(0))
+	c.Assert(other.Load().Sessions, Equals, int64(1))
+
+	// No new sessions are bound to the draining member
+	for i := 0; i < 3; i++ {
+		cli, err := client.Dial(context.Background(), gateNode.ClientAddr)
+		c.Assert(err, IsNil)
+		echo(cli)
+		cli.Close()
+	}
+	c.Assert(drained.Load().Sessions, Equals, int64(0))
+
+	// The draining gate stops accepting the clients and waits for the
+	// connected ones until the deadline
+	ctx, cancel = context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+	c.Assert(gateNode.Drain(ctx), Equals, context.DeadlineExceeded)
+	_, err = client.Dial(context.Background(), gateNode.ClientAddr)
+	c.Assert(err, NotNil)
+}
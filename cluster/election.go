@@ -0,0 +1,438 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cluster
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/lonng/nano/cluster/clusterpb"
+	"github.com/lonng/nano/internal/log"
+)
+
+// election elects a leader among the master nodes. The leader grants a lease
+// to the other masters periodically, which carries the member table, and a
+// follower campaigns for the leadership once the lease expires. A leader which
+// cannot renew the lease on a majority of masters steps down before the lease
+// of any follower could expire, see leaderLease.
+type election struct {
+	mu          sync.Mutex
+	term        uint64    // current term
+	votedFor    string    // candidate that received vote in current term
+	leaderAddr  string    // current leader address
+	isLeader    bool      // whether current node is the leader
+	alone       bool      // whether current node is the only master
+	leaseExpire time.Time // lease granted by the leader will expire at
+	campaignAt  time.Time // follower campaigns for the leadership at
+	quorumAt    time.Time // when the last lease accepted by a majority was sent
+	electedAt   time.Time // when current node became the leader
+
+	changed chan struct{} // signals the member table to be replicated
+}
+
+// peers returns the addresses of the other master nodes
+func (c *cluster) peers() []string {
+	var peers []string
+	for _, addr := range c.currentNode.masterAddrs() {
+		if addr != c.currentNode.ServiceAddr {
+			peers = append(peers, addr)
+		}
+	}
+	return peers
+}
+
+// isLeader reports whether current node is the leader and still holds the
+// lease on a majority of masters
+func (c *cluster) isLeader() bool {
+	c.election.mu.Lock()
+	defer c.election.mu.Unlock()
+	return c.election.isLeader && c.leaseValid()
+}
+
+// leaderLease is how long the leader keeps the leadership after sending a
+// lease which a majority accepted. The lease of a follower expires an election
+// timeout after received, so the margin covers the ticks and the clock drift.
+func (c *cluster) leaderLease() time.Duration {
+	timeout := c.currentNode.ElectionTimeout
+	return timeout - timeout/5
+}
+
+// leaseValid must be called with election lock held
+func (c *cluster) leaseValid() bool {
+	e := &c.election
+	return e.alone || time.Since(e.quorumAt) < c.leaderLease()
+}
+
+func (c *cluster) leader() string {
+	c.election.mu.Lock()
+	defer c.election.mu.Unlock()
+	return c.election.leaderAddr
+}
+
+// becomeLeader must be called with election lock held
+func (c *cluster) becomeLeader() {
+	e := &c.election
+	e.isLeader = true
+	e.leaderAddr = c.currentNode.ServiceAddr
+	// The leader serves once a majority accepted its lease
+	e.quorumAt = time.Time{}
+	e.electedAt = time.Now()
+	log.Println("Current node becomes the leader of masters, term", e.term)
+
+	masters := map[string]bool{}
+	for _, addr := range c.currentNode.masterAddrs() {
+		masters[addr] = true
+	}
+
+	// Give all members a grace period to find the new leader
+	c.mu.Lock()
+	for _, m := range c.members {
+		m.lastHeartbeatAt = time.Now()
+		if masters[m.memberInfo.ServiceAddr] {
+			m.isMaster = true
+		}
+	}
+	c.mu.Unlock()
+}
+
+// stepDown must be called with election lock held
+func (c *cluster) stepDown(term uint64) {
+	e := &c.election
+	if e.isLeader {
+		log.Println("Current node steps down from the leader of masters, term", term)
+	}
+	if term > e.term {
+		e.term = term
+		e.votedFor = ""
+	}
+	e.isLeader = false
+}
+
+// Vote implements the MasterServer gRPC service
+func (c *cluster) Vote(_ context.Context, req *clusterpb.VoteRequest) (*clusterpb.VoteResponse, error) {
+	e := &c.election
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if req.Term < e.term {
+		return &clusterpb.VoteResponse{Term: e.term}, nil
+	}
+	// Do not disturb a leader which still holds the lease
+	if e.isLeader || (e.leaderAddr != "" && time.Now().Before(e.leaseExpire)) {
+		return &clusterpb.VoteResponse{Term: e.term}, nil
+	}
+	if req.Term > e.term {
+		c.stepDown(req.Term)
+	}
+	granted := e.votedFor == "" || e.votedFor == req.CandidateAddr
+	if granted {
+		e.votedFor = req.CandidateAddr
+	}
+	return &clusterpb.VoteResponse{Term: e.term, Granted: granted}, nil
+}
+
+// Lease implements the MasterServer gRPC service
+func (c *cluster) Lease(_ context.Context, req *clusterpb.LeaseRequest) (*clusterpb.LeaseResponse, error) {
+	e := &c.election
+	e.mu.Lock()
+	if req.Term < e.term {
+		e.mu.Unlock()
+		return &clusterpb.LeaseResponse{Term: e.term}, nil
+	}
+	c.stepDown(req.Term)
+	if e.leaderAddr != req.LeaderAddr {
+		log.Println("Follow the leader of masters", req.LeaderAddr, "term", req.Term)
+	}
+	e.leaderAddr = req.LeaderAddr
+	e.votedFor = req.LeaderAddr
+	e.leaseExpire = time.Now().Add(c.currentNode.ElectionTimeout)
+	e.campaignAt = c.campaignTime(e.leaseExpire)
+	term := e.term
+	e.mu.Unlock()
+
+	c.syncMembers(req.Members)
+	c.currentNode.setLoads(req.Loads)
+	return &clusterpb.LeaseResponse{
+		Term:       term,
+		Accepted:   true,
+		MemberInfo: c.currentNode.memberInfo(),
+	}, nil
+}
+
+// syncMembers mirrors the member table of the leader
+func (c *cluster) syncMembers(infos []*clusterpb.MemberInfo) {
+	self := c.currentNode.ServiceAddr
+	known := map[string]bool{}
+	for _, addr := range c.remoteAddrs() {
+		known[addr] = true
+	}
+	latest := map[string]bool{}
+	for _, info := range infos {
+		latest[info.ServiceAddr] = true
+		if known[info.ServiceAddr] {
+			continue
+		}
+		if info.ServiceAddr != self {
+			c.currentNode.handler.addRemoteService(info)
+		}
+		c.addMember(info)
+	}
+	for addr := range known {
+		if latest[addr] || addr == self {
+			continue
+		}
+		c.currentNode.handler.delMember(addr)
+		c.delMember(addr)
+	}
+}
+
+func (c *cluster) campaign(peers []string) {
+	e := &c.election
+	e.mu.Lock()
+	e.term++
+	e.votedFor = c.currentNode.ServiceAddr
+	term := e.term
+	e.mu.Unlock()
+
+	log.Println("Campaign for the leader of masters, term", term)
+	request := &clusterpb.VoteRequest{Term: term, CandidateAddr: c.currentNode.ServiceAddr}
+	votes := 1
+	for _, addr := range peers {
+		pool, err := c.rpcClient.getConnPool(addr)
+		if err != nil {
+			continue
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), c.currentNode.ElectionTimeout/3)
+		resp, err := clusterpb.NewMasterClient(pool.Get()).Vote(ctx, request)
+		cancel()
+		if err != nil {
+			continue
+		}
+		if resp.Term > term {
+			e.mu.Lock()
+			c.stepDown(resp.Term)
+			e.mu.Unlock()
+			return
+		}
+		if resp.Granted {
+			votes++
+		}
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	// Someone else won or a newer term began during the campaign
+	if e.term != term || e.votedFor != c.currentNode.ServiceAddr {
+		return
+	}
+	if votes*2 > len(peers)+1 {
+		c.becomeLeader()
+	}
+}
+
+// replicate grants the lease with the member table to the other masters, and
+// returns the member information of the masters which accepted it
+func (c *cluster) replicate(peers []string) []*clusterpb.MemberInfo {
+	e := &c.election
+	e.mu.Lock()
+	term := e.term
+	e.mu.Unlock()
+
+	c.mu.RLock()
+	request := &clusterpb.LeaseRequest{Term: term, LeaderAddr: c.currentNode.ServiceAddr}
+	for _, m := range c.members {
+		request.Members = append(request.Members, m.memberInfo)
+	}
+	request.Loads = c.loads()
+	c.mu.RUnlock()
+
+	// The masters are called in parallel, so that the unavailable ones delay
+	// the replication by a timeout at most
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		accepted []*clusterpb.MemberInfo
+		newer    uint64
+	)
+	for _, addr := range peers {
+		pool, err := c.rpcClient.getConnPool(addr)
+		if err != nil {
+			continue
+		}
+		wg.Add(1)
+		go func(pool *connPool) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(context.Background(), c.currentNode.ElectionTimeout/3)
+			resp, err := clusterpb.NewMasterClient(pool.Get()).Lease(ctx, request)
+			cancel()
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			if resp.Accepted {
+				accepted = append(accepted, resp.MemberInfo)
+			} else if resp.Term > newer {
+				newer = resp.Term
+			}
+		}(pool)
+	}
+	wg.Wait()
+
+	if newer > term {
+		e.mu.Lock()
+		c.stepDown(newer)
+		e.mu.Unlock()
+		return nil
+	}
+	return accepted
+}
+
+// replicateLater replicates the member table to the other masters in the
+// background, the changes made meanwhile are replicated together
+func (c *cluster) replicateLater() {
+	select {
+	case c.election.changed <- struct{}{}:
+	default:
+	}
+}
+
+func (c *cluster) renewLease(peers []string) {
+	e := &c.election
+	e.mu.Lock()
+	term := e.term
+	e.mu.Unlock()
+
+	// The followers receive the lease after it is sent
+	sentAt := time.Now()
+	accepted := c.replicate(peers)
+	for _, info := range accepted {
+		c.touchMaster(info)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.isLeader && e.term == term && (len(accepted)+1)*2 > len(peers)+1 {
+		e.quorumAt = sentAt
+	}
+}
+
+// leading reports whether current node is the leader, it steps down if the
+// lease on a majority of masters expired, or no majority accepted the lease
+// since elected
+func (c *cluster) leading() bool {
+	e := &c.election
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.isLeader && !c.leaseValid() && time.Since(e.electedAt) >= c.leaderLease() {
+		c.stepDown(e.term)
+	}
+	return e.isLeader
+}
+
+// touchMaster records a follower master into the member table
+func (c *cluster) touchMaster(info *clusterpb.MemberInfo) {
+	if info == nil {
+		return
+	}
+	c.mu.Lock()
+	for _, m := range c.members {
+		if m.memberInfo.ServiceAddr == info.ServiceAddr {
+			m.isMaster = true
+			m.lastHeartbeatAt = time.Now()
+			c.mu.Unlock()
+			return
+		}
+	}
+	c.mu.Unlock()
+
+	if _, err := c.Register(context.Background(), &clusterpb.RegisterRequest{MemberInfo: info}); err != nil {
+		log.Println("Register follower master failed", info.ServiceAddr, err)
+		return
+	}
+	c.touchMaster(info)
+}
+
+// campaignTime randomizes the campaign after the lease expiration to avoid
+// split votes
+func (c *cluster) campaignTime(leaseExpire time.Time) time.Time {
+	return leaseExpire.Add(time.Duration(rand.Int63n(int64(c.currentNode.ElectionTimeout))))
+}
+
+// elect runs the election until the cluster is closed
+func (c *cluster) elect() {
+	peers := c.peers()
+	if len(peers) == 0 {
+		c.election.mu.Lock()
+		c.election.alone = true
+		c.becomeLeader()
+		c.election.mu.Unlock()
+		return
+	}
+
+	timeout := c.currentNode.ElectionTimeout
+	c.election.mu.Lock()
+	c.election.leaseExpire = time.Now().Add(timeout)
+	c.election.campaignAt = c.campaignTime(c.election.leaseExpire)
+	c.election.mu.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(timeout / 10)
+		defer ticker.Stop()
+		var renewAt time.Time
+		for {
+			select {
+			case <-c.election.changed:
+				if c.leading() {
+					c.renewLease(peers)
+					renewAt = time.Now().Add(timeout / 3)
+				}
+			case <-ticker.C:
+				if c.leading() {
+					if time.Now().After(renewAt) {
+						c.renewLease(peers)
+						renewAt = time.Now().Add(timeout / 3)
+					}
+					continue
+				}
+				c.election.mu.Lock()
+				campaign := time.Now().After(c.election.campaignAt)
+				c.election.mu.Unlock()
+				if !campaign {
+					continue
+				}
+				c.campaign(peers)
+				if c.leading() {
+					c.renewLease(peers)
+					renewAt = time.Now().Add(timeout / 3)
+					continue
+				}
+				c.election.mu.Lock()
+				c.election.campaignAt = c.campaignTime(time.Now())
+				c.election.mu.Unlock()
+			case <-c.die:
+				return
+			}
+		}
+	}()
+}
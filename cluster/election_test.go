@@ -0,0 +1,122 @@
+package cluster_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/lonng/nano/benchmark/testdata"
+	"github.com/lonng/nano/cluster"
+	"github.com/lonng/nano/component"
+	. "github.com/pingcap/check"
+)
+
+// startMasters starts n master nodes which elect a leader among them
+func startMasters(c *C, n int) ([]*cluster.Node, []string) {
+	addrs := freeAddrs(c, n)
+	var masters []*cluster.Node
+	for _, addr := range addrs {
+		node := &cluster.Node{
+			Options: cluster.Options{
+				IsMaster:        true,
+				AdvertiseAddrs:  addrs,
+				ElectionTimeout: 300 * time.Millisecond,
+				Components:      &component.Components{},
+			},
+			ServiceAddr: addr,
+		}
+		c.Assert(node.Startup(), IsNil)
+		masters = append(masters, node)
+	}
+	return masters, addrs
+}
+
+// waitLeader waits for the nodes agree on a leader among them
+func waitLeader(c *C, nodes []*cluster.Node) *cluster.Node {
+	var leader *cluster.Node
+	waitFor(c, 5*time.Second, func() bool {
+		leader = nil
+		for _, n := range nodes {
+			if n.Leader() == n.ServiceAddr {
+				leader = n
+			}
+		}
+		for _, n := range nodes {
+			if leader == nil || n.Leader() != leader.ServiceAddr {
+				return false
+			}
+		}
+		return true
+	})
+	return leader
+}
+
+func (s *nodeSuite) TestMasterElection(c *C) {
+	masters, addrs := startMasters(c, 3)
+	leader := waitLeader(c, masters)
+
+	gameComps := &component.Components{}
+	gameComps.Register(&GameComponent{})
+	gameNode := startNode(c, cluster.Options{
+		AdvertiseAddrs: addrs,
+		RetryInterval:  100 * time.Millisecond,
+		Components:     gameComps,
+	})
+	defer gameNode.Shutdown()
+	c.Assert(gameNode.Leader(), Equals, leader.ServiceAddr)
+
+	// Wait for the followers mirror the game member from the leader
+	waitFor(c, 5*time.Second, func() bool {
+		for _, n := range masters {
+			if n != leader && len(n.Handler().RemoteService()) == 0 {
+				return false
+			}
+		}
+		return true
+	})
+
+	// Shutdown the leader and wait for the followers elect a new one
+	var followers []*cluster.Node
+	for _, n := range masters {
+		if n != leader {
+			followers = append(followers, n)
+			defer n.Shutdown()
+		}
+	}
+	leader.Shutdown()
+	newLeader := waitLeader(c, followers)
+	c.Assert(newLeader.ServiceAddr, Not(Equals), leader.ServiceAddr)
+
+	// New member registers to the new leader, and the member table
+	// mirrored from the previous leader is still available
+	gateNode := startNode(c, cluster.Options{
+		AdvertiseAddrs: addrs,
+		RetryInterval:  100 * time.Millisecond,
+	})
+	defer gateNode.Shutdown()
+	c.Assert(gateNode.Leader(), Equals, newLeader.ServiceAddr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	pong := &testdata.Pong{}
+	c.Assert(gateNode.Call(ctx, "GameComponent.Test4", &testdata.Ping{Content: "ping"}, pong), IsNil)
+	c.Assert(pong.Content, Equals, "ping")
+}
+
+func (s *nodeSuite) TestLeaderStepDown(c *C) {
+	masters, _ := startMasters(c, 3)
+	leader := waitLeader(c, masters)
+	defer leader.Shutdown()
+	waitFor(c, time.Second, leader.IsLeader)
+
+	// The leader stops serving before the leases of the followers expire, which
+	// is an election timeout after they are shut down at most
+	var shutdownAt time.Time
+	for _, n := range masters {
+		if n != leader {
+			n.Shutdown()
+			shutdownAt = time.Now()
+		}
+	}
+	waitFor(c, time.Second, func() bool { return !leader.IsLeader() })
+	c.Assert(time.Since(shutdownAt) < 300*time.Millisecond, IsTrue)
+}
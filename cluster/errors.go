@@ -20,11 +20,58 @@
 
 package cluster
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+)
 
 // Errors that could be occurred during message handling.
 var (
 	ErrSessionOnNotify    = errors.New("current session working on notify mode")
 	ErrCloseClosedSession = errors.New("close closed session")
 	ErrInvalidRegisterReq = errors.New("invalid register request")
+
+	ErrInvalidRoute        = errors.New("invalid route")
+	ErrServiceNotFound     = errors.New("service not found")
+	ErrCallTimeout         = errors.New("call timeout")
+	ErrCallDeadlock        = errors.New("call the handler running on the scheduler of the caller")
+	ErrMismatchedReply     = errors.New("reply does not match the call")
+	ErrSessionNotConnected = errors.New("session is not connected to a client")
+	ErrNoNodeContext       = errors.New("context is not created by a node")
+	ErrNotLeader           = errors.New("current master is not the leader")
+	ErrStreamClosed        = errors.New("stream closed")
+	ErrSessionNotForwarded = errors.New("session is not forwarded from a gate")
+	ErrSessionMoved        = errors.New("session moved to another member")
 )
+
+// internalErrorMessage is the message of the non-structured errors responded to
+// the client, which hides the details of the errors
+const internalErrorMessage = "internal error"
+
+// RemoteError represents an error returned by the handler of a call, the code is
+// the one of the structured error, or ErrCodeInternal if the error is not
+// structured.
+type RemoteError struct {
+	Route   string
+	Code    int
+	Message string
+}
+
+func (e *RemoteError) Error() string {
+	return fmt.Sprintf("%s: %s (code: %d)", e.Route, e.Message, e.Code)
+}
+
+// MulticastError is returned by Multicast if the message failed to be pushed to
+// some of the sessions or the gates, the failures are logged as well
+type MulticastError struct {
+	Errors []error
+}
+
+func (e *MulticastError) Error() string {
+	return fmt.Sprintf("multicast failed %d time(s), last error: %v", len(e.Errors), e.Unwrap())
+}
+
+// Unwrap returns the last failure
+func (e *MulticastError) Unwrap() error {
+	return e.Errors[len(e.Errors)-1]
+}
@@ -0,0 +1,59 @@
+package cluster
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/lonng/nano/cluster/clusterpb"
+	"github.com/lonng/nano/session"
+	"google.golang.org/grpc"
+)
+
+// ParkedSessions returns the number of the sessions waiting to be resumed
+func (n *Node) ParkedSessions() int {
+	n.handler.resumeMu.Lock()
+	defer n.handler.resumeMu.Unlock()
+	return len(n.handler.parked)
+}
+
+// IsLeader reports whether the master node is the leader which holds the lease
+// on a majority of masters
+func (n *Node) IsLeader() bool {
+	return n.cluster.isLeader()
+}
+
+// Batchers returns the number of the gates which the messages are batched to
+func (n *Node) Batchers() int {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return len(n.batchers)
+}
+
+// HoldSession marks the forwarded session moving, the messages of the session
+// wait until release is called, then the session is still served
+func HoldSession(s *session.Session) (release func()) {
+	ac := s.NetworkEntity().(*acceptor)
+	moved := ac.node.beginMove(ac.sid)
+	return func() { ac.node.endMove(ac.sid, s, moved, false) }
+}
+
+// rebindFailer fails the next rebinds of the session
+type rebindFailer struct {
+	clusterpb.MemberClient
+	err   error
+	times int32
+}
+
+func (f *rebindFailer) RebindSession(ctx context.Context, req *clusterpb.RebindSessionRequest, opts ...grpc.CallOption) (*clusterpb.RebindSessionResponse, error) {
+	if atomic.AddInt32(&f.times, -1) >= 0 {
+		return nil, f.err
+	}
+	return f.MemberClient.RebindSession(ctx, req, opts...)
+}
+
+// FailRebind makes the gate fail to rebind the forwarded session the next
+// times with err, it should be called in the scheduler of the session
+func FailRebind(s *session.Session, times int, err error) {
+	ac := s.NetworkEntity().(*acceptor)
+	ac.gateClient = &rebindFailer{MemberClient: ac.gateClient, err: err, times: int32(times)}
+}
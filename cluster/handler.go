@@ -28,27 +28,26 @@ import (
 	"net"
 	"reflect"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
 	"github.com/lonng/nano/cluster/clusterpb"
 	"github.com/lonng/nano/component"
 	"github.com/lonng/nano/internal/codec"
-	"github.com/lonng/nano/internal/env"
 	"github.com/lonng/nano/internal/log"
 	"github.com/lonng/nano/internal/message"
 	"github.com/lonng/nano/internal/packet"
+	"github.com/lonng/nano/internal/wsconn"
 	"github.com/lonng/nano/pipeline"
 	"github.com/lonng/nano/scheduler"
 	"github.com/lonng/nano/session"
-)
-
-var (
-	// cached serialized data
-	hrd []byte // handshake response data
-	hbd []byte // heartbeat packet data
+	"github.com/lonng/nano/tracing"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type rpcHandler func(session *session.Session, msg *message.Message, noCopy bool)
@@ -56,23 +55,15 @@ type rpcHandler func(session *session.Session, msg *message.Message, noCopy bool
 // CustomerRemoteServiceRoute customer remote service route
 type CustomerRemoteServiceRoute func(service string, session *session.Session, members []*clusterpb.MemberInfo) *clusterpb.MemberInfo
 
-func cache() {
-	hrdata := map[string]interface{}{
-		"code": 200,
-		"sys": map[string]interface{}{
-			"heartbeat":  env.Heartbeat.Seconds(),
-			"servertime": time.Now().UTC().Unix(),
-		},
+// cache caches the handshake response and heartbeat packet data
+func (h *LocalHandler) cache() {
+	n := h.currentNode
+	h.hrsys = map[string]interface{}{
+		"heartbeat":  n.Heartbeat.Seconds(),
+		"servertime": time.Now().UTC().Unix(),
 	}
-	if dict, ok := message.GetDictionary(); ok {
-		hrdata = map[string]interface{}{
-			"code": 200,
-			"sys": map[string]interface{}{
-				"heartbeat":  env.Heartbeat.Seconds(),
-				"servertime": time.Now().UTC().Unix(),
-				"dict":       dict,
-			},
-		}
+	if len(n.Dictionary) > 0 {
+		h.hrsys["dict"] = n.Dictionary
 	}
 	// data, err := json.Marshal(map[string]interface{}{
 	// 	"code": 200,
@@ -80,22 +71,39 @@ func cache() {
 	// 		"heartbeat": env.Heartbeat.Seconds(),
 	// 	},
 	// })
-	data, err := json.Marshal(hrdata)
+	data, err := json.Marshal(map[string]interface{}{"code": 200, "sys": h.hrsys})
 	if err != nil {
 		panic(err)
 	}
 
-	hrd, err = codec.Encode(packet.Handshake, data)
+	h.hrd, err = codec.Encode(packet.Handshake, data)
 	if err != nil {
 		panic(err)
 	}
 
-	hbd, err = codec.Encode(packet.Heartbeat, nil)
+	h.hbd, err = codec.Encode(packet.Heartbeat, nil)
 	if err != nil {
 		panic(err)
 	}
 }
 
+// handshakeResponse returns the handshake response data which carries the
+// resume token of the session and whether the session has been resumed
+func (h *LocalHandler) handshakeResponse(token string, resumed bool) ([]byte, error) {
+	if token == "" {
+		return h.hrd, nil
+	}
+	sys := map[string]interface{}{"resume": token, "resumed": resumed}
+	for k, v := range h.hrsys {
+		sys[k] = v
+	}
+	data, err := json.Marshal(map[string]interface{}{"code": 200, "sys": sys})
+	if err != nil {
+		return nil, err
+	}
+	return codec.Encode(packet.Handshake, data)
+}
+
 type LocalHandler struct {
 	localServices map[string]*component.Service // all registered service
 	localHandlers map[string]*component.Handler // all handler method
@@ -105,6 +113,18 @@ type LocalHandler struct {
 
 	pipeline    pipeline.Pipeline
 	currentNode *Node
+	calls       *pendingCalls
+	callSeq     uint64
+
+	resumeMu sync.Mutex
+	parked   map[string]*agent // parked agents indexed by resume token
+
+	tracer *tracing.Tracer // nil if tracing disabled
+
+	// cached serialized data
+	hrd   []byte                 // handshake response data
+	hbd   []byte                 // heartbeat packet data
+	hrsys map[string]interface{} // handshake response sys data
 }
 
 func NewHandler(currentNode *Node, pipeline pipeline.Pipeline) *LocalHandler {
@@ -114,6 +134,11 @@ func NewHandler(currentNode *Node, pipeline pipeline.Pipeline) *LocalHandler {
 		remoteServices: map[string][]*clusterpb.MemberInfo{},
 		pipeline:       pipeline,
 		currentNode:    currentNode,
+		calls:          newPendingCalls(currentNode.Serializer),
+		parked:         map[string]*agent{},
+	}
+	if exporter := currentNode.TraceExporter; exporter != nil {
+		h.tracer = tracing.NewTracer(exporter)
 	}
 
 	return h
@@ -140,12 +165,6 @@ func (h *LocalHandler) register(comp component.Component, opts []component.Optio
 	return nil
 }
 
-func (h *LocalHandler) initRemoteService(members []*clusterpb.MemberInfo) {
-	for _, m := range members {
-		h.addRemoteService(m)
-	}
-}
-
 func (h *LocalHandler) addRemoteService(member *clusterpb.MemberInfo) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
@@ -201,43 +220,26 @@ func (h *LocalHandler) RemoteService() []string {
 
 func (h *LocalHandler) handle(conn net.Conn) {
 	// create a client agent and startup write gorontine
-	agent := newAgent(conn, h.pipeline, h.remoteProcess)
+	agent := newAgent(h.currentNode, conn, h.pipeline, h.remoteProcess, h.call, h.calls)
+	agent.resumable = h.currentNode.ResumeWindow > 0
 	h.currentNode.storeSession(agent.session)
+	h.currentNode.conns.Increment()
 
 	// startup write goroutine
 	go agent.write()
 
-	if env.Debug {
+	if h.currentNode.Debug {
 		log.Println(fmt.Sprintf("New session established: %s", agent.String()))
 	}
 
 	// guarantee agent related resource be destroyed
 	defer func() {
-		request := &clusterpb.SessionClosedRequest{
-			SessionId: agent.session.ID(),
-		}
-
-		members := h.currentNode.cluster.remoteAddrs()
-		for _, remote := range members {
-			log.Println("Notify remote server", remote)
-			pool, err := h.currentNode.rpcClient.getConnPool(remote)
-			if err != nil {
-				log.Println("Cannot retrieve connection pool for address", remote, err)
-				continue
-			}
-			client := clusterpb.NewMemberClient(pool.Get())
-			_, err = client.SessionClosed(context.Background(), request)
-			if err != nil {
-				log.Println("Cannot closed session in remote address", remote, err)
-				continue
-			}
-			if env.Debug {
-				log.Println("Notify remote server success", remote)
-			}
+		h.currentNode.conns.Decrement()
+		if h.park(agent) {
+			return
 		}
-
-		agent.Close()
-		if env.Debug {
+		h.closeAgent(agent)
+		if h.currentNode.Debug {
 			log.Println(fmt.Sprintf("Session read goroutine exit, SessionID=%d, UID=%d", agent.session.ID(), agent.session.UID()))
 		}
 	}()
@@ -276,25 +278,73 @@ func (h *LocalHandler) handle(conn net.Conn) {
 	}
 }
 
+// closeAgent closes the agent and notifies the remote servers the session closed
+func (h *LocalHandler) closeAgent(agent *agent) {
+	request := &clusterpb.SessionClosedRequest{
+		SessionId: agent.session.ID(),
+	}
+
+	members := h.currentNode.cluster.remoteAddrs()
+	for _, remote := range members {
+		log.Println("Notify remote server", remote)
+		// Notify through the stream after the messages forwarded before
+		if s := h.currentNode.backendStreams.get(remote); s != nil {
+			if err := s.Send(&clusterpb.StreamMessage{SessionClosed: request}); err == nil {
+				continue
+			}
+		}
+		pool, err := h.currentNode.rpcClient.getConnPool(remote)
+		if err != nil {
+			log.Println("Cannot retrieve connection pool for address", remote, err)
+			continue
+		}
+		client := clusterpb.NewMemberClient(pool.Get())
+		_, err = client.SessionClosed(context.Background(), request)
+		if err != nil {
+			log.Println("Cannot closed session in remote address", remote, err)
+			continue
+		}
+		if h.currentNode.Debug {
+			log.Println("Notify remote server success", remote)
+		}
+	}
+
+	agent.Close()
+}
+
 func (h *LocalHandler) processPacket(agent *agent, p *packet.Packet) error {
 	switch p.Type {
 	case packet.Handshake:
-		if err := env.HandshakeValidator(agent.session, p.Data); err != nil {
+		if err := h.currentNode.HandshakeValidator(agent.session, p.Data); err != nil {
+			h.currentNode.metrics.handshakesTotal.Inc("rejected")
 			return err
 		}
 
-		if _, err := agent.conn.Write(hrd); err != nil {
+		parked := h.resume(agent, p.Data)
+		if parked != nil {
+			h.currentNode.metrics.handshakesTotal.Inc("resumed")
+		} else {
+			h.currentNode.metrics.handshakesTotal.Inc("accepted")
+		}
+		data, err := h.handshakeResponse(agent.token, parked != nil)
+		if err == nil {
+			_, err = agent.conn.Write(data)
+		}
+		if parked != nil {
+			agent.resumed(parked)
+		}
+		if err != nil {
 			return err
 		}
 
 		agent.setStatus(statusHandshake)
-		if env.Debug {
+		if h.currentNode.Debug {
 			log.Println(fmt.Sprintf("Session handshake Id=%d, Remote=%s", agent.session.ID(), agent.conn.RemoteAddr()))
 		}
 
 	case packet.HandshakeAck:
 		agent.setStatus(statusWorking)
-		if env.Debug {
+		if h.currentNode.Debug {
 			log.Println(fmt.Sprintf("Receive handshake ACK Id=%d, Remote=%s", agent.session.ID(), agent.conn.RemoteAddr()))
 		}
 
@@ -304,7 +354,7 @@ func (h *LocalHandler) processPacket(agent *agent, p *packet.Packet) error {
 				agent.conn.RemoteAddr().String())
 		}
 
-		msg, err := message.Decode(p.Data)
+		msg, err := h.currentNode.dict.Decode(p.Data)
 		if err != nil {
 			return err
 		}
@@ -314,7 +364,7 @@ func (h *LocalHandler) processPacket(agent *agent, p *packet.Packet) error {
 		// expected
 	}
 
-	agent.lastAt = time.Now().Unix()
+	atomic.StoreInt64(&agent.lastAt, time.Now().Unix())
 	return nil
 }
 
@@ -324,57 +374,61 @@ func (h *LocalHandler) findMembers(service string) []*clusterpb.MemberInfo {
 	return h.remoteServices[service]
 }
 
-func (h *LocalHandler) remoteProcess(session *session.Session, msg *message.Message, noCopy bool) {
-	index := strings.LastIndex(msg.Route, ".")
-	if index < 0 {
-		log.Println(fmt.Sprintf("nano/handler: invalid route %s", msg.Route))
-		return
+// provides returns whether the member of addr provides the service
+func (h *LocalHandler) provides(service, addr string) bool {
+	for _, m := range h.findMembers(service) {
+		if m.ServiceAddr == addr {
+			return true
+		}
 	}
+	return false
+}
 
-	service := msg.Route[:index]
+// selectRemote returns the address of the member which serves the service for
+// the session, the selection will be bound to the session router
+func (h *LocalHandler) selectRemote(service string, session *session.Session) (string, error) {
 	members := h.findMembers(service)
 	if len(members) == 0 {
-		log.Println(fmt.Sprintf("nano/handler: %s not found(forgot registered?)", msg.Route))
-		return
+		return "", ErrServiceNotFound
 	}
 
 	// Select a remote service address
-	// 1. if exist customer remote service route ,use it, otherwise use default strategy
-	// 2. Use the service address directly if the router contains binding item
+	// 1. Use the service address directly if the router contains binding item
+	// 2. if exist customer remote service route ,use it, otherwise use the
+	//    balancer of the service or the default balancer
 	// 3. Select a remote service address randomly and bind to router
-	var remoteAddr string
-	if h.currentNode.Options.RemoteServiceRoute != nil {
-		if addr, found := session.Router().Find(service); found {
-			remoteAddr = addr
-		} else {
-			member := h.currentNode.Options.RemoteServiceRoute(service, session, members)
-			if member == nil {
-				log.Println(fmt.Sprintf("customize remoteServiceRoute handler: %s is not found", msg.Route))
-				return
-			}
-			remoteAddr = member.ServiceAddr
-			session.Router().Bind(service, remoteAddr)
-		}
+	if addr, found := session.Router().Find(service); found {
+		return addr, nil
+	}
+	// Never bind the sessions to the draining members
+	if members = serving(members); len(members) == 0 {
+		return "", ErrServiceNotFound
+	}
+	var member *clusterpb.MemberInfo
+	if route := h.currentNode.Options.RemoteServiceRoute; route != nil {
+		member = route(service, session, members)
+	} else if balancer := h.balancer(service); balancer != nil {
+		member = balancer.Select(service, session, members, h.currentNode.MemberLoad)
 	} else {
-		if addr, found := session.Router().Find(service); found {
-			remoteAddr = addr
-		} else {
-			remoteAddr = members[rand.Intn(len(members))].ServiceAddr
-			session.Router().Bind(service, remoteAddr)
-		}
+		member = members[rand.Intn(len(members))]
 	}
-	pool, err := h.currentNode.rpcClient.getConnPool(remoteAddr)
-	if err != nil {
-		log.Println(err)
-		return
+	if member == nil {
+		return "", ErrServiceNotFound
 	}
-	var data = msg.Data
-	if !noCopy && len(msg.Data) > 0 {
-		data = make([]byte, len(msg.Data))
-		copy(data, msg.Data)
+	session.Router().Bind(service, member.ServiceAddr)
+	return member.ServiceAddr, nil
+}
+
+// balancer returns the balancer of the service, or nil if not specified
+func (h *LocalHandler) balancer(service string) Balancer {
+	if b, found := h.currentNode.Balancers[service]; found {
+		return b
 	}
+	return h.currentNode.Balancer
+}
 
-	// Retrieve gate address and session id
+// origin returns the gate address and the session id on the gate of session
+func (h *LocalHandler) origin(session *session.Session) (string, int64) {
 	gateAddr := h.currentNode.ServiceAddr
 	sessionId := session.ID()
 	switch v := session.NetworkEntity().(type) {
@@ -382,30 +436,275 @@ func (h *LocalHandler) remoteProcess(session *session.Session, msg *message.Mess
 		gateAddr = v.gateAddr
 		sessionId = v.sid
 	}
+	return gateAddr, sessionId
+}
 
-	client := clusterpb.NewMemberClient(pool.Get())
-	switch msg.Type {
-	case message.Request:
-		request := &clusterpb.RequestMessage{
+func (h *LocalHandler) remoteProcess(session *session.Session, msg *message.Message, noCopy bool) {
+	h.forward(context.Background(), session, msg, noCopy)
+}
+
+// forward forwards the message to the remote member which provides the
+// service, the trace context carried by ctx is propagated in the metadata
+func (h *LocalHandler) forward(ctx context.Context, session *session.Session, msg *message.Message, noCopy bool) {
+	index := strings.LastIndex(msg.Route, ".")
+	if index < 0 {
+		log.Println(fmt.Sprintf("nano/handler: invalid route %s", msg.Route))
+		return
+	}
+	if msg.Type != message.Request && msg.Type != message.Notify {
+		return
+	}
+	var data = msg.Data
+	if !noCopy && len(msg.Data) > 0 {
+		data = make([]byte, len(msg.Data))
+		copy(data, msg.Data)
+	}
+
+	service := msg.Route[:index]
+	for retried := false; ; retried = true {
+		err := h.forwardTo(ctx, session, service, msg, data)
+		// The session moved to another member before the message arrived,
+		// forward it again to the member which the session is rebound to
+		if status.Code(err) == codes.Aborted && !retried {
+			continue
+		}
+		if err != nil && msg.Type == message.Request {
+			h.fail(session, msg.ID, err)
+		}
+		return
+	}
+}
+
+// forwardTo forwards the message to the member selected for the service
+func (h *LocalHandler) forwardTo(ctx context.Context, session *session.Session, service string, msg *message.Message, data []byte) error {
+	remoteAddr, err := h.selectRemote(service, session)
+	if err != nil {
+		log.Println(fmt.Sprintf("nano/handler: %s not found(forgot registered?)", msg.Route))
+		return err
+	}
+	pool, err := h.currentNode.rpcClient.getConnPool(remoteAddr)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	// Retrieve gate address and session id
+	gateAddr, sessionId := h.origin(session)
+
+	span := h.tracer.Start("forward "+msg.Route, tracing.SpanContextFromContext(ctx))
+	span.SetAttribute("nano.member", remoteAddr)
+	defer span.End()
+	metadata := tracing.Inject(nil, span.SpanContext())
+
+	var request *clusterpb.RequestMessage
+	var notify *clusterpb.NotifyMessage
+	if msg.Type == message.Request {
+		request = &clusterpb.RequestMessage{
 			GateAddr:  gateAddr,
 			SessionId: sessionId,
 			Id:        msg.ID,
 			Route:     msg.Route,
 			Data:      data,
+			Metadata:  metadata,
+			Values:    session.ReplicatedValues(),
+			Uid:       session.UID(),
 		}
-		_, err = client.HandleRequest(context.Background(), request)
-	case message.Notify:
-		request := &clusterpb.NotifyMessage{
+	} else {
+		notify = &clusterpb.NotifyMessage{
 			GateAddr:  gateAddr,
 			SessionId: sessionId,
 			Route:     msg.Route,
 			Data:      data,
+			Metadata:  metadata,
+			Values:    session.ReplicatedValues(),
+			Uid:       session.UID(),
 		}
-		_, err = client.HandleNotify(context.Background(), request)
 	}
+
+	start := time.Now()
+	timeout := h.currentNode.RequestTimeout
+	sent := false
+	if h.currentNode.ForwardStream {
+		// The stream keeps the messages of the session in order, and the
+		// deadline is propagated in the metadata
+		if request != nil && timeout > 0 {
+			request.Metadata = setMetadata(request.Metadata, timeoutKey, timeout.String())
+		}
+		sent = h.currentNode.sendStream(remoteAddr, &clusterpb.StreamMessage{Request: request, Notify: notify})
+	}
+	if !sent {
+		// The deadline is propagated to the handler through the gRPC boundary
+		if timeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		client := clusterpb.NewMemberClient(pool.Get())
+		if request != nil {
+			_, err = client.HandleRequest(ctx, request)
+		} else {
+			_, err = client.HandleNotify(ctx, notify)
+		}
+	}
+	h.currentNode.metrics.forwardDuration.Observe(time.Since(start).Seconds(), remoteAddr, strings.ToLower(msg.Type.String()))
 	if err != nil {
+		span.SetError(err)
 		log.Println(fmt.Sprintf("Process remote message (%d:%s) error: %+v", msg.ID, msg.Route, err))
 	}
+	return err
+}
+
+// fail terminates the request with err, the error is delivered to the caller
+// if the request is a call, otherwise it is responded to the client
+func (h *LocalHandler) fail(session *session.Session, mid uint64, err error) {
+	if mid >= callIDBase {
+		h.calls.fail(mid, err)
+		return
+	}
+	if mid == 0 {
+		return
+	}
+
+	if err := session.ResponseMID(mid, clientError(err)); err != nil {
+		log.Println(fmt.Sprintf("Response error (%d) failed: %+v", mid, err))
+	}
+}
+
+// clientError returns the error responded to the client, the details of the
+// non-structured errors are kept in the server logs only
+func clientError(err error) *message.Error {
+	switch e := err.(type) {
+	case *message.Error:
+		return e
+	case *RemoteError:
+		// The structured error returned by the remote handler
+		if e.Code != message.ErrCodeInternal {
+			return &message.Error{Code: e.Code, Message: e.Message}
+		}
+	}
+	if err == ErrServiceNotFound {
+		return &message.Error{Code: message.ErrCodeNotFound, Message: err.Error()}
+	}
+	return &message.Error{Code: message.ErrCodeInternal, Message: internalErrorMessage}
+}
+
+// errorCode returns the code of the error, ErrCodeInternal if the error is not
+// structured
+func errorCode(err error) int {
+	switch e := err.(type) {
+	case *message.Error:
+		return e.Code
+	case *RemoteError:
+		return e.Code
+	}
+	if err == ErrServiceNotFound {
+		return message.ErrCodeNotFound
+	}
+	return message.ErrCodeInternal
+}
+
+// call sends a request to the handler of route and waits for the response, the
+// session is nil when the call is session-less
+func (h *LocalHandler) call(ctx context.Context, session *session.Session, route string, v, reply interface{}) error {
+	if strings.LastIndex(route, ".") < 0 {
+		return ErrInvalidRoute
+	}
+	data, err := h.currentNode.serialize(v)
+	if err != nil {
+		return err
+	}
+
+	var payload []byte
+	if handler, found := h.localHandlers[route]; found {
+		payload, err = h.localCall(ctx, handler, session, route, data)
+	} else {
+		payload, err = h.remoteCall(ctx, session, route, data)
+		// The session moved to another member before the call arrived, call
+		// the member which the session is rebound to
+		if status.Code(err) == codes.Aborted && session != nil {
+			payload, err = h.remoteCall(ctx, session, route, data)
+		}
+	}
+	if err != nil {
+		return err
+	}
+	return decodeReply(h.currentNode.Serializer, payload, reply)
+}
+
+func (h *LocalHandler) localCall(ctx context.Context, handler *component.Handler, s *session.Session, route string, data []byte) ([]byte, error) {
+	if s == nil {
+		c := &callee{calls: h.calls, rpcHandler: h.remoteProcess, callHandler: h.call}
+		s = h.currentNode.newSession(c)
+		c.session = s
+	}
+	// The handler would never run if the caller blocks its scheduler
+	if local, err := h.scheduler(route[:strings.LastIndex(route, ".")], s); err == nil && scheduler.IsCurrent(ctx, local) {
+		return nil, ErrCallDeadlock
+	}
+	id, ch := h.calls.add()
+	msg := &message.Message{
+		Type:  message.Request,
+		ID:    id,
+		Route: route,
+		Data:  data,
+	}
+	h.localProcess(ctx, handler, id, s, msg)
+	return h.calls.wait(ctx, id, ch)
+}
+
+func (h *LocalHandler) remoteCall(ctx context.Context, session *session.Session, route string, data []byte) ([]byte, error) {
+	service := route[:strings.LastIndex(route, ".")]
+	request := &clusterpb.CallRequest{
+		Id:    atomic.AddUint64(&h.callSeq, 1),
+		Route: route,
+		Data:  data,
+	}
+
+	var remoteAddr string
+	if session != nil {
+		addr, err := h.selectRemote(service, session)
+		if err != nil {
+			return nil, err
+		}
+		remoteAddr = addr
+		request.GateAddr, request.SessionId = h.origin(session)
+		request.Values = session.ReplicatedValues()
+		request.Uid = session.UID()
+	} else {
+		members := h.findMembers(service)
+		if len(members) == 0 {
+			return nil, ErrServiceNotFound
+		}
+		remoteAddr = members[rand.Intn(len(members))].ServiceAddr
+	}
+
+	pool, err := h.currentNode.rpcClient.getConnPool(remoteAddr)
+	if err != nil {
+		return nil, err
+	}
+	span := h.tracer.Start("forward "+route, tracing.SpanContextFromContext(ctx))
+	span.SetAttribute("nano.member", remoteAddr)
+	defer span.End()
+	request.Metadata = tracing.Inject(nil, span.SpanContext())
+
+	client := clusterpb.NewMemberClient(pool.Get())
+	start := time.Now()
+	resp, err := client.HandleCall(ctx, request)
+	h.currentNode.metrics.forwardDuration.Observe(time.Since(start).Seconds(), remoteAddr, "call")
+	if err != nil {
+		span.SetError(err)
+		if status.Code(err) == codes.DeadlineExceeded {
+			return nil, ErrCallTimeout
+		}
+		return nil, err
+	}
+	if resp.Id != request.Id {
+		return nil, ErrMismatchedReply
+	}
+	if resp.Error != "" {
+		return nil, &RemoteError{Route: route, Code: int(resp.Code), Message: resp.Error}
+	}
+	return resp.Data, nil
 }
 
 func (h *LocalHandler) processMessage(agent *agent, msg *message.Message) {
@@ -420,28 +719,42 @@ func (h *LocalHandler) processMessage(agent *agent, msg *message.Message) {
 		return
 	}
 
+	// The trace of a client message starts here
+	span := h.tracer.Start(msg.Route, tracing.SpanContext{})
+	span.SetAttribute("nano.session_id", strconv.FormatInt(agent.session.ID(), 10))
+	defer span.End()
+	ctx := tracing.ContextWithSpan(context.Background(), span)
+
 	handler, found := h.localHandlers[msg.Route]
 	if !found {
-		h.remoteProcess(agent.session, msg, false)
+		h.forward(ctx, agent.session, msg, false)
 	} else {
-		h.localProcess(handler, lastMid, agent.session, msg)
+		h.localProcess(ctx, handler, lastMid, agent.session, msg)
 	}
 }
 
 func (h *LocalHandler) handleWS(conn *websocket.Conn) {
-	c, err := newWSConn(conn)
-	if err != nil {
-		log.Println(err)
-		return
-	}
-	go h.handle(c)
+	go h.handle(wsconn.New(conn))
 }
 
-func (h *LocalHandler) localProcess(handler *component.Handler, lastMid uint64, session *session.Session, msg *message.Message) {
+// localProcess dispatches the message to the local handler, a context-aware
+// handler inherits the deadline and the trace context of ctx
+func (h *LocalHandler) localProcess(ctx context.Context, handler *component.Handler, lastMid uint64, session *session.Session, msg *message.Message) {
+	span := h.tracer.Start("handle "+msg.Route, tracing.SpanContextFromContext(ctx))
+	scheduled := false
+	defer func() {
+		if !scheduled {
+			span.End()
+		}
+	}()
+
 	if pipe := h.pipeline; pipe != nil {
 		err := pipe.Inbound().Process(session, msg)
 		if err != nil {
 			log.Println("Pipeline process failed: " + err.Error())
+			h.currentNode.metrics.pipelineRejections.Inc(msg.Route)
+			span.SetError(err)
+			h.fail(session, lastMid, err)
 			return
 		}
 	}
@@ -452,57 +765,201 @@ func (h *LocalHandler) localProcess(handler *component.Handler, lastMid uint64,
 		data = payload
 	} else {
 		data = reflect.New(handler.Type.Elem()).Interface()
-		err := env.Serializer.Unmarshal(payload, data)
+		err := h.currentNode.Serializer.Unmarshal(payload, data)
 		if err != nil {
 			log.Println(fmt.Sprintf("Deserialize to %T failed: %+v (%v)", data, err, payload))
+			span.SetError(err)
+			h.fail(session, lastMid, err)
 			return
 		}
 	}
 
-	if env.Debug {
+	if h.currentNode.Debug {
 		log.Println(fmt.Sprintf("UID=%d, Message={%s}, Data=%+v", session.UID(), msg.String(), data))
 	}
 
+	index := strings.LastIndex(msg.Route, ".")
+	if index < 0 {
+		log.Println(fmt.Sprintf("nano/handler: invalid route %s", msg.Route))
+		return
+	}
+
+	local, err := h.scheduler(msg.Route[:index], session)
+	if err != nil {
+		h.fail(session, lastMid, err)
+		return
+	}
+
 	args := []reflect.Value{handler.Receiver, reflect.ValueOf(session), reflect.ValueOf(data)}
 	task := func() {
+		defer span.End()
+		// The session migrated to another member after the message was
+		// scheduled, the message is relayed to the member except the calls,
+		// which are called again by the callers
+		if ac, ok := session.NetworkEntity().(*acceptor); ok {
+			if target := ac.migratedTo(); target != "" {
+				err := ErrSessionMoved
+				if lastMid < callIDBase {
+					err = h.currentNode.relay(ac, target, msg, span.SpanContext())
+				}
+				if err != nil {
+					span.SetError(err)
+					h.fail(session, lastMid, err)
+				}
+				return
+			}
+		}
+		var tracker *requests
 		switch v := session.NetworkEntity().(type) {
 		case *agent:
 			v.lastMid = lastMid
+			tracker = &v.requests
 		case *acceptor:
 			v.lastMid = lastMid
+			v.lastSpan = span.SpanContext()
+			tracker = &v.requests
+		case *callee:
+			v.lastMid = lastMid
 		}
-
-		result := handler.Method.Func.Call(args)
-		if len(result) > 0 {
-			if err := result[0].Interface(); err != nil {
-				log.Println(fmt.Sprintf("Service %s error: %+v", msg.Route, err))
+		tracker.begin(lastMid)
+
+		start := time.Now()
+		var result []reflect.Value
+		if handler.IsContext {
+			ctx, cancel := h.handlerContext(ctx, session, lastMid)
+			ctx = tracing.ContextWithSpan(ctx, span)
+			ctx = scheduler.WithCurrent(ctx, local)
+			result = handler.Method.Func.Call(append([]reflect.Value{handler.Receiver, reflect.ValueOf(ctx)}, args[1:]...))
+			cancel()
+		} else {
+			result = handler.Method.Func.Call(args)
+		}
+		h.currentNode.metrics.requestsTotal.Inc(msg.Route, strings.ToLower(msg.Type.String()))
+		h.currentNode.metrics.requestDuration.Observe(time.Since(start).Seconds(), msg.Route)
+		// The client has got the response of the request
+		responded := tracker.end(lastMid)
+		if err := result[len(result)-1].Interface(); err != nil {
+			span.SetError(err.(error))
+			log.Println(fmt.Sprintf("Service %s error: %+v", msg.Route, err))
+			if !responded {
+				h.fail(session, lastMid, err.(error))
+			}
+			return
+		}
+		// Respond the returned value of a context-aware handler automatically
+		if handler.IsContext && lastMid > 0 && !responded && !result[0].IsNil() {
+			if err := session.ResponseMID(lastMid, result[0].Interface()); err != nil {
+				log.Println(fmt.Sprintf("Service %s response error: %+v", msg.Route, err))
 			}
 		}
 	}
 
-	index := strings.LastIndex(msg.Route, ".")
-	if index < 0 {
-		log.Println(fmt.Sprintf("nano/handler: invalid route %s", msg.Route))
-		return
-	}
+	scheduled = true
+	local.Schedule(task)
+}
 
-	// A message can be dispatch to global thread or a user customized thread
-	service := msg.Route[:index]
-	if s, found := h.localServices[service]; found && s.SchedName != "" {
+// scheduler returns the scheduler which runs the handlers of the service for
+// the session, a message can be dispatched to the global thread, the actors or
+// a user customized thread
+func (h *LocalHandler) scheduler(service string, session *session.Session) (scheduler.LocalScheduler, error) {
+	s, found := h.localServices[service]
+	switch {
+	case found && s.Actor:
+		return h.currentNode.actors.Scheduler(actorKey(s, session)), nil
+	case found && s.SchedName != "":
 		sched := session.Value(s.SchedName)
 		if sched == nil {
 			log.Println(fmt.Sprintf("nanl/handler: cannot found `schedular.LocalScheduler` by %s", s.SchedName))
-			return
+			return nil, ErrServiceNotFound
 		}
 
 		local, ok := sched.(scheduler.LocalScheduler)
 		if !ok {
 			log.Println(fmt.Sprintf("nanl/handler: Type %T does not implement the `schedular.LocalScheduler` interface",
 				sched))
-			return
+			return nil, ErrServiceNotFound
 		}
-		local.Schedule(task)
-	} else {
-		scheduler.PushTask(task)
+		return local, nil
+	case found && s.Scheduler != "":
+		return scheduler.Named(s.Scheduler), nil
+	default:
+		return h.currentNode.Scheduler, nil
+	}
+}
+
+type (
+	sessionActor int64 // actor of a session
+	valueActor   struct {
+		key   string
+		value interface{}
+	} // actor of a value in the session data
+)
+
+// actorKey returns the key of the actor which schedules the messages of the
+// session for the service
+func actorKey(s *component.Service, session *session.Session) interface{} {
+	if s.ActorKey == "" {
+		return sessionActor(session.ID())
+	}
+	value := session.Value(s.ActorKey)
+	if value == nil {
+		return sessionActor(session.ID())
+	}
+	if !reflect.TypeOf(value).Comparable() {
+		value = fmt.Sprint(value)
 	}
+	return valueActor{key: s.ActorKey, value: value}
+}
+
+// setMetadata sets the value of key in the metadata, and allocates the metadata
+// if it is nil
+func setMetadata(metadata map[string]string, key, value string) map[string]string {
+	if metadata == nil {
+		metadata = map[string]string{}
+	}
+	metadata[key] = value
+	return metadata
+}
+
+// requests tracks whether the requests being handled have been responded, so
+// that the error or the value returned by a handler which has responded is not
+// responded again
+type requests struct {
+	mu        sync.Mutex
+	responded map[uint64]bool // indexed by the message id
+}
+
+// begin starts tracking the request of mid, the nil tracker does nothing
+func (r *requests) begin(mid uint64) {
+	if r == nil || mid == 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.responded == nil {
+		r.responded = map[uint64]bool{}
+	}
+	r.responded[mid] = false
+}
+
+// respond marks the request of mid responded if it is being tracked
+func (r *requests) respond(mid uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, found := r.responded[mid]; found {
+		r.responded[mid] = true
+	}
+}
+
+// end stops tracking the request of mid and returns whether it has been
+// responded
+func (r *requests) end(mid uint64) bool {
+	if r == nil || mid == 0 {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	responded := r.responded[mid]
+	delete(r.responded, mid)
+	return responded
 }
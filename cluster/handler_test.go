@@ -0,0 +1,215 @@
+package cluster_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/lonng/nano/benchmark/testdata"
+	"github.com/lonng/nano/client"
+	"github.com/lonng/nano/cluster"
+	"github.com/lonng/nano/component"
+	"github.com/lonng/nano/internal/message"
+	"github.com/lonng/nano/internal/packet"
+	"github.com/lonng/nano/scheduler"
+	"github.com/lonng/nano/serialize/protobuf"
+	"github.com/lonng/nano/session"
+	. "github.com/pingcap/check"
+)
+
+func (c *GameComponent) Test7(session *session.Session, ping *testdata.Ping) error {
+	return &message.Error{Code: 1001, Message: ping.Content}
+}
+
+func (c *GameComponent) Test8(session *session.Session, ping *testdata.Ping) error {
+	if err := session.Response(&testdata.Pong{Content: "responded"}); err != nil {
+		return err
+	}
+	return errors.New("failed after responded")
+}
+
+func (s *nodeSuite) TestErrorResponse(c *C) {
+	masterNode := startNode(c, cluster.Options{IsMaster: true})
+	defer masterNode.Shutdown()
+
+	gateComps := &component.Components{}
+	gateComps.Register(&GateComponent{})
+	gateNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		ClientAddr:    "127.0.0.1:0",
+		Components:    gateComps,
+	})
+	defer gateNode.Shutdown()
+
+	gameComps := &component.Components{}
+	gameComps.Register(&GameComponent{})
+	gameNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		Components:    gameComps,
+	})
+	defer gameNode.Shutdown()
+
+	client, _ := dialRaw(c, gateNode.ClientAddr, `{"sys":{}}`)
+	defer client.conn.Close()
+	request := func(id uint64, route string) *message.Error {
+		data, err := protobuf.NewSerializer().Marshal(&testdata.Ping{Content: "not enough gold"})
+		c.Assert(err, IsNil)
+		m, err := message.Encode(&message.Message{Type: message.Request, ID: id, Route: route, Data: data})
+		c.Assert(err, IsNil)
+		client.write(c, packet.Data, m)
+
+		p := client.read(c)
+		resp, err := message.Decode(p.Data)
+		c.Assert(err, IsNil)
+		c.Assert(resp.ID, Equals, id)
+		c.Assert(resp.Err, IsTrue)
+		e, err := message.DecodeError(resp.Data)
+		c.Assert(err, IsNil)
+		return e
+	}
+
+	// Structured error returned by the handler on the backend
+	c.Assert(request(1, "GameComponent.Test7"), DeepEquals, &message.Error{Code: 1001, Message: "not enough gold"})
+	// Plain error returned by the handler
+	c.Assert(request(2, "GameComponent.Test3"), DeepEquals, &message.Error{Code: message.ErrCodeInternal, Message: "internal error"})
+	// Service not found
+	c.Assert(request(3, "UnknownComponent.Test").Code, Equals, message.ErrCodeNotFound)
+
+	// The error returned after the response is not responded
+	data, err := protobuf.NewSerializer().Marshal(&testdata.Ping{})
+	c.Assert(err, IsNil)
+	m, err := message.Encode(&message.Message{Type: message.Request, ID: 4, Route: "GameComponent.Test8", Data: data})
+	c.Assert(err, IsNil)
+	client.write(c, packet.Data, m)
+	pong := &testdata.Pong{}
+	resp := client.readMessage(c, pong)
+	c.Assert(resp.ID, Equals, uint64(4))
+	c.Assert(resp.Err, IsFalse)
+	c.Assert(pong.Content, Equals, "responded")
+	c.Assert(request(5, "GameComponent.Test7").Code, Equals, 1001)
+}
+
+type ActorComponent struct {
+	component.Base
+	blocked chan struct{}
+	release chan struct{}
+}
+
+func (c *ActorComponent) Join(s *session.Session, ping *testdata.Ping) error {
+	s.Set("room", ping.Content)
+	return s.Response(&testdata.Pong{Content: "joined"})
+}
+
+func (c *ActorComponent) Block(s *session.Session, _ *testdata.Ping) error {
+	c.blocked <- struct{}{}
+	<-c.release
+	return s.Response(&testdata.Pong{Content: "released"})
+}
+
+func (c *ActorComponent) Ping(s *session.Session, _ *testdata.Ping) error {
+	return s.Response(&testdata.Pong{Content: "pong"})
+}
+
+func (s *nodeSuite) TestActorScheduler(c *C) {
+	comps := &component.Components{}
+	actor := &ActorComponent{blocked: make(chan struct{}, 1), release: make(chan struct{})}
+	comps.Register(actor, component.WithActorKey("room"))
+	node := startNode(c, cluster.Options{
+		ClientAddr:   "127.0.0.1:0",
+		Components:   comps,
+		ActorWorkers: 4,
+	})
+	defer node.Shutdown()
+
+	// Release the blocked handler before shutdown even if failed
+	var once sync.Once
+	release := func() { once.Do(func() { close(actor.release) }) }
+	defer release()
+
+	request := func(cli *client.Client, route, content string) chan string {
+		result := make(chan string, 1)
+		go func() {
+			pong := &testdata.Pong{}
+			if err := cli.Request(context.Background(), route, &testdata.Ping{Content: content}, pong); err != nil {
+				result <- err.Error()
+				return
+			}
+			result <- pong.Content
+		}()
+		return result
+	}
+	var clients []*client.Client
+	for _, room := range []string{"r1", "r1", "r2"} {
+		cli, err := client.Dial(context.Background(), node.ClientAddr)
+		c.Assert(err, IsNil)
+		defer cli.Close()
+		c.Assert(<-request(cli, "ActorComponent.Join", room), Equals, "joined")
+		clients = append(clients, cli)
+	}
+
+	blocked := request(clients[0], "ActorComponent.Block", "")
+	<-actor.blocked
+
+	// The messages of the same room run in order, and those of the other
+	// rooms run in parallel
+	sameRoom := request(clients[1], "ActorComponent.Ping", "")
+	select {
+	case pong := <-request(clients[2], "ActorComponent.Ping", ""):
+		c.Assert(pong, Equals, "pong")
+	case <-time.After(time.Second):
+		c.Fatal("the other room is blocked")
+	}
+	select {
+	case <-sameRoom:
+		c.Fatal("the same room runs in parallel")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	release()
+	c.Assert(<-blocked, Equals, "released")
+	c.Assert(<-sameRoom, Equals, "pong")
+}
+
+type NamedComponent struct{ component.Base }
+
+func (c *NamedComponent) Ping(s *session.Session, _ *testdata.Ping) error {
+	return s.Response(&testdata.Pong{Content: "pong"})
+}
+
+func (s *nodeSuite) TestNamedScheduler(c *C) {
+	comps := &component.Components{}
+	comps.Register(&NamedComponent{}, component.WithNamedScheduler("named"))
+	node := startNode(c, cluster.Options{
+		ClientAddr: "127.0.0.1:0",
+		Components: comps,
+	})
+	defer node.Shutdown()
+
+	// The named scheduler shared with the other node keeps running after the
+	// other node shut down
+	otherComps := &component.Components{}
+	otherComps.Register(&NamedComponent{}, component.WithNamedScheduler("named"))
+	other := startNode(c, cluster.Options{Components: otherComps})
+	other.Shutdown()
+
+	cli, err := client.Dial(context.Background(), node.ClientAddr)
+	c.Assert(err, IsNil)
+	defer cli.Close()
+
+	// Block the default scheduler until the test finished
+	blocked, release := make(chan struct{}), make(chan struct{})
+	defer close(release)
+	scheduler.PushTask(func() {
+		close(blocked)
+		<-release
+	})
+	<-blocked
+
+	// The handlers of the component run on the named scheduler
+	pong := &testdata.Pong{}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	c.Assert(cli.Request(ctx, "NamedComponent.Ping", &testdata.Ping{}, pong), IsNil)
+	c.Assert(pong.Content, Equals, "pong")
+}
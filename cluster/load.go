@@ -0,0 +1,122 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cluster
+
+import (
+	"runtime"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/lonng/nano/cluster/clusterpb"
+)
+
+// cpuSampler samples the CPU usage of current process
+type cpuSampler struct {
+	mu      sync.Mutex
+	lastAt  time.Time
+	lastCPU time.Duration
+	usage   float64
+}
+
+// sample returns the CPU usage in cores averaged since the previous sample,
+// the previous usage is reused if sampled within a second
+func (s *cpuSampler) sample() float64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now, cpu := time.Now(), cpuTime()
+	if elapsed := now.Sub(s.lastAt); elapsed >= time.Second {
+		if !s.lastAt.IsZero() {
+			s.usage = float64(cpu-s.lastCPU) / float64(elapsed)
+		}
+		s.lastAt, s.lastCPU = now, cpu
+	}
+	return s.usage
+}
+
+// Load returns the load of current node, which is reported to the master in
+// the heartbeats
+func (n *Node) Load() *clusterpb.MemberLoad {
+	n.mu.RLock()
+	sessions := len(n.sessions)
+	scheds := n.scheds
+	n.mu.RUnlock()
+
+	backlog := n.Scheduler.QueueDepth() + n.actors.QueueDepth()
+	for _, s := range scheds {
+		backlog += s.QueueDepth()
+	}
+
+	load := &clusterpb.MemberLoad{
+		ServiceAddr:      n.ServiceAddr,
+		Sessions:         int64(sessions),
+		SchedulerBacklog: int64(backlog),
+		Cpu:              n.cpu.sample(),
+		Goroutines:       int64(runtime.NumGoroutine()),
+		ReportedAt:       time.Now().UnixNano() / int64(time.Millisecond),
+	}
+	if len(n.LoadGauges) > 0 {
+		load.Gauges = make(map[string]float64, len(n.LoadGauges))
+		for name, gauge := range n.LoadGauges {
+			load.Gauges[name] = gauge()
+		}
+	}
+	return load
+}
+
+// MemberLoad returns the load reported by the member of the service address,
+// or nil if the member has not reported yet
+func (n *Node) MemberLoad(addr string) *clusterpb.MemberLoad {
+	if addr == n.ServiceAddr {
+		return n.Load()
+	}
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.loads[addr]
+}
+
+// MemberLoads returns the loads of current node and the members which have
+// reported, which are sorted by the service address. The loads are collected
+// by the master and shared with the members in the heartbeat responses.
+func (n *Node) MemberLoads() []*clusterpb.MemberLoad {
+	loads := []*clusterpb.MemberLoad{n.Load()}
+	n.mu.RLock()
+	for addr, load := range n.loads {
+		if addr != n.ServiceAddr {
+			loads = append(loads, load)
+		}
+	}
+	n.mu.RUnlock()
+	sort.Slice(loads, func(i, j int) bool { return loads[i].ServiceAddr < loads[j].ServiceAddr })
+	return loads
+}
+
+// setLoads replaces the loads of the members
+func (n *Node) setLoads(loads []*clusterpb.MemberLoad) {
+	m := make(map[string]*clusterpb.MemberLoad, len(loads))
+	for _, l := range loads {
+		m[l.ServiceAddr] = l
+	}
+	n.mu.Lock()
+	n.loads = m
+	n.mu.Unlock()
+}
@@ -0,0 +1,58 @@
+package cluster_test
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/lonng/nano/client"
+	"github.com/lonng/nano/cluster"
+	"github.com/lonng/nano/component"
+	. "github.com/pingcap/check"
+)
+
+func (s *nodeSuite) TestMemberLoad(c *C) {
+	masterNode := startNode(c, cluster.Options{IsMaster: true})
+	defer masterNode.Shutdown()
+
+	gameComps := &component.Components{}
+	gameComps.Register(&GameComponent{})
+	gameNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		Components:    gameComps,
+		LoadGauges:    map[string]func() float64{"rooms": func() float64 { return 3 }},
+	})
+	defer gameNode.Shutdown()
+
+	gateNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		ClientAddr:    "127.0.0.1:0",
+	})
+	defer gateNode.Shutdown()
+
+	cli, err := client.Dial(context.Background(), gateNode.ClientAddr)
+	c.Assert(err, IsNil)
+	defer cli.Close()
+
+	load := gateNode.Load()
+	c.Assert(load.ServiceAddr, Equals, gateNode.ServiceAddr)
+	c.Assert(load.Sessions, Equals, int64(1))
+	c.Assert(load.Goroutines > 0, IsTrue)
+	c.Assert(load.ReportedAt > 0, IsTrue)
+
+	// The members report the load once joined, and the master shares the
+	// loads of all members in the heartbeat responses
+	waitFor(c, time.Second, func() bool { return gateNode.MemberLoad(gameNode.ServiceAddr) != nil })
+	c.Assert(gateNode.MemberLoad(gameNode.ServiceAddr).Gauges["rooms"], Equals, float64(3))
+	c.Assert(gateNode.MemberLoad(masterNode.ServiceAddr), NotNil)
+	c.Assert(masterNode.MemberLoad(gateNode.ServiceAddr), NotNil)
+	c.Assert(masterNode.MemberLoad(gameNode.ServiceAddr), NotNil)
+
+	var addrs []string
+	for _, l := range gateNode.MemberLoads() {
+		addrs = append(addrs, l.ServiceAddr)
+	}
+	expected := []string{masterNode.ServiceAddr, gameNode.ServiceAddr, gateNode.ServiceAddr}
+	sort.Strings(expected)
+	c.Assert(addrs, DeepEquals, expected)
+}
@@ -31,6 +31,7 @@ type Member struct {
 	isMaster        bool
 	memberInfo      *clusterpb.MemberInfo
 	lastHeartbeatAt time.Time // cluster member report heartbeat time to the master
+	load            *clusterpb.MemberLoad
 }
 
 func (m *Member) MemberInfo() *clusterpb.MemberInfo {
@@ -0,0 +1,79 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cluster
+
+import (
+	"github.com/lonng/nano/metrics"
+)
+
+// nodeMetrics holds the built-in metrics of a node, which are exposed along with
+// the metrics registered to metrics.DefaultRegistry
+type nodeMetrics struct {
+	registry           *metrics.Registry
+	handshakesTotal    *metrics.Counter
+	requestsTotal      *metrics.Counter
+	requestDuration    *metrics.Histogram
+	pipelineRejections *metrics.Counter
+	bufferExceeded     *metrics.Counter
+	forwardDuration    *metrics.Histogram
+	batchFailures      *metrics.Counter
+	rebindFailures     *metrics.Counter
+}
+
+func newNodeMetrics(n *Node) *nodeMetrics {
+	m := &nodeMetrics{
+		registry: metrics.NewRegistry(),
+		handshakesTotal: metrics.NewCounter("nano_handshakes_total",
+			"Number of handshakes by result.", "result"),
+		requestsTotal: metrics.NewCounter("nano_requests_total",
+			"Number of messages handled by the local handlers.", "route", "type"),
+		requestDuration: metrics.NewHistogram("nano_request_duration_seconds",
+			"Latency of the local handlers.", metrics.DefBuckets, "route"),
+		pipelineRejections: metrics.NewCounter("nano_pipeline_rejections_total",
+			"Number of messages rejected by the inbound pipeline.", "route"),
+		bufferExceeded: metrics.NewCounter("nano_buffer_exceeded_total",
+			"Number of messages dropped since the session send buffer exceeded."),
+		forwardDuration: metrics.NewHistogram("nano_forward_duration_seconds",
+			"Latency of forwarding the messages to the remote members.", metrics.DefBuckets, "member", "type"),
+		batchFailures: metrics.NewCounter("nano_batch_failures_total",
+			"Number of the batched messages failed to be flushed to the gates.", "gate"),
+		rebindFailures: metrics.NewCounter("nano_rebind_failures_total",
+			"Number of the sessions failed to be rebound by the gates while moving to other members."),
+	}
+	m.registry.Register(
+		metrics.NewGaugeFunc("nano_sessions_active",
+			"Number of client sessions connected to current node.",
+			func() float64 { return float64(n.conns.Count()) }),
+		m.handshakesTotal,
+		m.requestsTotal,
+		m.requestDuration,
+		m.pipelineRejections,
+		m.bufferExceeded,
+		metrics.NewGaugeFunc("nano_scheduler_queue_depth",
+			"Number of tasks waiting to be scheduled.",
+			func() float64 { return float64(n.Scheduler.QueueDepth()) }),
+		m.forwardDuration,
+		m.batchFailures,
+		m.rebindFailures,
+		metrics.DefaultRegistry,
+	)
+	return m
+}
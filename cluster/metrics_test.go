@@ -0,0 +1,83 @@
+package cluster_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/lonng/nano/benchmark/testdata"
+	"github.com/lonng/nano/client"
+	"github.com/lonng/nano/cluster"
+	"github.com/lonng/nano/component"
+	"github.com/lonng/nano/metrics"
+	"github.com/lonng/nano/service"
+	. "github.com/pingcap/check"
+)
+
+var appRequests = metrics.NewCounter("app_test_requests_total", "Number of the test requests.")
+
+func init() {
+	metrics.Register(appRequests)
+}
+
+func (s *nodeSuite) TestMetrics(c *C) {
+	gameComps := &component.Components{}
+	gameComps.Register(&GameComponent{})
+	node := startNode(c, cluster.Options{
+		IsMaster:    true,
+		ClientAddr:  "127.0.0.1:0",
+		MetricsAddr: "127.0.0.1:0",
+		Components:  gameComps,
+	})
+	defer node.Shutdown()
+
+	// The connections of all nodes are counted by the connection service
+	connected := service.Connections.Count()
+	cli, err := client.Dial(context.Background(), node.ClientAddr)
+	c.Assert(err, IsNil)
+	defer cli.Close()
+	waitFor(c, time.Second, func() bool { return service.Connections.Count() == connected+1 })
+	pong := &testdata.Pong{}
+	c.Assert(cli.Request(context.Background(), "GameComponent.Echo", &testdata.Ping{Content: "ping"}, pong), IsNil)
+
+	resp, err := http.Get("http://" + node.MetricsAddr + "/metrics")
+	c.Assert(err, IsNil)
+	defer resp.Body.Close()
+	c.Assert(resp.Header.Get("Content-Type"), Equals, metrics.ContentType)
+	body, err := ioutil.ReadAll(resp.Body)
+	c.Assert(err, IsNil)
+	c.Assert(string(body), Matches, `(?s).*\nnano_sessions_active [1-9].*`)
+	c.Assert(string(body), Matches, `(?s).*\nnano_handshakes_total\{result="accepted"\} [1-9].*`)
+	c.Assert(string(body), Matches, `(?s).*\nnano_requests_total\{route="GameComponent.Echo",type="request"\} [1-9].*`)
+	c.Assert(string(body), Matches, `(?s).*\nnano_request_duration_seconds_count\{route="GameComponent.Echo"\} [1-9].*`)
+	c.Assert(string(body), Matches, `(?s).*\nnano_scheduler_queue_depth \d+\n.*`)
+
+	// The metrics of the application are exposed along with the built-in ones
+	appRequests.Inc()
+	buf := &bytes.Buffer{}
+	c.Assert(node.Metrics().Write(buf), IsNil)
+	c.Assert(buf.String(), Matches, `(?s).*\napp_test_requests_total [1-9].*`)
+
+	// The built-in metrics are scoped to each node, and are absent before the
+	// node started
+	other := &cluster.Node{
+		Options:     cluster.Options{IsMaster: true, Components: &component.Components{}},
+		ServiceAddr: "127.0.0.1:0",
+	}
+	c.Assert(other.Metrics(), IsNil)
+	c.Assert(other.Startup(), IsNil)
+	buf.Reset()
+	c.Assert(other.Metrics().Write(buf), IsNil)
+	c.Assert(buf.String(), Matches, `(?s).*\nnano_sessions_active 0\n.*`)
+	c.Assert(buf.String(), Not(Matches), `(?s).*nano_requests_total\{.*`)
+
+	// The node shut down again is left alone
+	other.Shutdown()
+	other.Shutdown()
+	c.Assert(other.Load(), NotNil)
+
+	cli.Close()
+	waitFor(c, time.Second, func() bool { return service.Connections.Count() == connected })
+}
@@ -0,0 +1,247 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cluster
+
+import (
+	"bytes"
+	"context"
+	"encoding/gob"
+	"fmt"
+
+	"github.com/lonng/nano/cluster/clusterpb"
+	"github.com/lonng/nano/internal/log"
+	"github.com/lonng/nano/internal/message"
+	"github.com/lonng/nano/session"
+	"github.com/lonng/nano/tracing"
+)
+
+// StateSerializer serializes the session state values migrated between the members
+type StateSerializer interface {
+	Marshal(key string, value interface{}) ([]byte, error)
+	Unmarshal(key string, data []byte) (interface{}, error)
+}
+
+// gobSerializer is the default StateSerializer, the value types other than the
+// basic types must be registered via gob.Register
+type gobSerializer struct{}
+
+func (gobSerializer) Marshal(_ string, value interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := gob.NewEncoder(buf).Encode(&value); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (gobSerializer) Unmarshal(_ string, data []byte) (interface{}, error) {
+	var value interface{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// MigrateSession migrates the session forwarded from a gate to the member target
+// via the node which the session is forwarded to, see Node.MigrateSession.
+func MigrateSession(ctx context.Context, s *session.Session, target string) error {
+	ac, ok := s.NetworkEntity().(*acceptor)
+	if !ok {
+		return ErrSessionNotForwarded
+	}
+	return ac.node.MigrateSession(ctx, s, target)
+}
+
+// MigrateSession migrates the session forwarded from a gate to the member target,
+// e.g. to hand off the session while draining or to rebalance the rooms. The UID
+// and the state of the session are restored on target, then the gate rebinds the
+// services bound to current node to target if target provides them, otherwise the
+// services will be rebound to the other members on demand. The session is
+// forgotten by current node without being closed. If the gate fails to rebind
+// the session, current node keeps serving it and target drops the restored one.
+//
+// The messages of the session arriving during the migration wait until the gate
+// rebinds the session, then are forwarded again by the gate, or responded with
+// an error through the forward streams.
+func (n *Node) MigrateSession(ctx context.Context, s *session.Session, target string) error {
+	ac, ok := s.NetworkEntity().(*acceptor)
+	if !ok {
+		return ErrSessionNotForwarded
+	}
+	if target == n.ServiceAddr {
+		return nil
+	}
+
+	// The session is moving already while being handed off, which forgets it
+	// once the gate rebinds it
+	moved := n.beginMove(ac.sid)
+	end := func(rebound bool) {
+		if moved != nil {
+			n.endMove(ac.sid, s, moved, rebound)
+		} else if rebound {
+			n.removeSession(ac.sid)
+		}
+	}
+
+	serializer := n.stateSerializer()
+	request := &clusterpb.RestoreSessionRequest{
+		SessionId: ac.sid,
+		GateAddr:  ac.gateAddr,
+		Uid:       s.UID(),
+		State:     map[string][]byte{},
+	}
+	for key, value := range s.State() {
+		if value == nil {
+			continue
+		}
+		data, err := serializer.Marshal(key, value)
+		if err != nil {
+			end(false)
+			return err
+		}
+		request.State[key] = data
+	}
+
+	pool, err := n.rpcClient.getConnPool(target)
+	if err != nil {
+		end(false)
+		return err
+	}
+	client := clusterpb.NewMemberClient(pool.Get())
+	if _, err := client.RestoreSession(ctx, request); err != nil {
+		end(false)
+		return err
+	}
+	ac.migrated.Store(target)
+	err = ac.rebind(n.ServiceAddr, target)
+	if err != nil {
+		// The session is still served by current node, and the copy restored
+		// on target is dropped
+		log.Println(fmt.Sprintf("Rebind session %d to %s failed: %v", s.ID(), target, err))
+		n.metrics.rebindFailures.Inc()
+		ac.migrated.Store("")
+		end(false)
+		discard := &clusterpb.RestoreSessionRequest{SessionId: ac.sid, Discard: true}
+		if _, e := client.RestoreSession(ctx, discard); e != nil {
+			log.Println(fmt.Sprintf("Discard session %d on %s failed: %v", s.ID(), target, e))
+		}
+		return err
+	}
+	ac.cancel()
+	end(true)
+	return nil
+}
+
+// RestoreSession implements the MemberServer interface
+func (n *Node) RestoreSession(_ context.Context, req *clusterpb.RestoreSessionRequest) (*clusterpb.RestoreSessionResponse, error) {
+	if req.Discard {
+		// The session failed to be rebound is forgotten without being closed
+		n.mu.Lock()
+		s, found := n.sessions[req.SessionId]
+		delete(n.sessions, req.SessionId)
+		n.mu.Unlock()
+		if found {
+			if ac, ok := s.NetworkEntity().(*acceptor); ok {
+				ac.cancel()
+			}
+		}
+		return &clusterpb.RestoreSessionResponse{}, nil
+	}
+
+	serializer := n.stateSerializer()
+	state := make(map[string]interface{}, len(req.State))
+	for key, data := range req.State {
+		value, err := serializer.Unmarshal(key, data)
+		if err != nil {
+			return nil, err
+		}
+		state[key] = value
+	}
+
+	// The session may be migrated back to current node
+	n.mu.Lock()
+	if moved, moving := n.moving[req.SessionId]; moving {
+		select {
+		case <-moved:
+			delete(n.moving, req.SessionId)
+		default:
+		}
+	}
+	n.mu.Unlock()
+
+	s, err := n.findOrCreateSession(req.SessionId, req.GateAddr)
+	if err != nil {
+		return nil, err
+	}
+	if err := n.updateSession(s, req.Uid, nil); err != nil {
+		return nil, err
+	}
+	s.Restore(state)
+	return &clusterpb.RestoreSessionResponse{}, nil
+}
+
+// relay forwards the message of the session, which was scheduled before the
+// session migrated, to the member which the session migrated to
+func (n *Node) relay(ac *acceptor, target string, msg *message.Message, sc tracing.SpanContext) error {
+	pool, err := n.rpcClient.getConnPool(target)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	if n.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, n.RequestTimeout)
+		defer cancel()
+	}
+	metadata := tracing.Inject(nil, sc)
+	client := clusterpb.NewMemberClient(pool.Get())
+	s := ac.session
+	if msg.Type == message.Request {
+		_, err = client.HandleRequest(ctx, &clusterpb.RequestMessage{
+			GateAddr:  ac.gateAddr,
+			SessionId: ac.sid,
+			Id:        msg.ID,
+			Route:     msg.Route,
+			Data:      msg.Data,
+			Metadata:  metadata,
+			Values:    s.ReplicatedValues(),
+			Uid:       s.UID(),
+		})
+		return err
+	}
+	_, err = client.HandleNotify(ctx, &clusterpb.NotifyMessage{
+		GateAddr:  ac.gateAddr,
+		SessionId: ac.sid,
+		Route:     msg.Route,
+		Data:      msg.Data,
+		Metadata:  metadata,
+		Values:    s.ReplicatedValues(),
+		Uid:       s.UID(),
+	})
+	return err
+}
+
+// stateSerializer returns the serializer of the migrated session state
+func (n *Node) stateSerializer() StateSerializer {
+	if n.StateSerializer != nil {
+		return n.StateSerializer
+	}
+	return gobSerializer{}
+}
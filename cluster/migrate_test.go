@@ -0,0 +1,112 @@
+package cluster_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/lonng/nano/benchmark/testdata"
+	"github.com/lonng/nano/client"
+	"github.com/lonng/nano/cluster"
+	"github.com/lonng/nano/component"
+	"github.com/lonng/nano/session"
+	. "github.com/pingcap/check"
+)
+
+type CountComponent struct {
+	component.Base
+	counted chan *session.Session
+}
+
+func (c *CountComponent) Count(s *session.Session, _ *testdata.Ping) error {
+	if s.UID() == 0 {
+		s.Bind(42)
+	}
+	count := s.Int("count") + 1
+	s.Set("count", count)
+	select {
+	case c.counted <- s:
+	default:
+	}
+	return s.Response(&testdata.Pong{Content: fmt.Sprintf("%d %d", s.UID(), count)})
+}
+
+func (c *CountComponent) FailRebind(s *session.Session, _ *testdata.Ping) error {
+	cluster.FailRebind(s, 1, errors.New("rebind failed"))
+	return s.Response(&testdata.Pong{Content: "failing"})
+}
+
+func (c *CountComponent) Migrate(s *session.Session, ping *testdata.Ping) error {
+	return cluster.MigrateSession(context.Background(), s, ping.Content)
+}
+
+func (s *nodeSuite) TestMigrateSession(c *C) {
+	masterNode := startNode(c, cluster.Options{IsMaster: true})
+	defer masterNode.Shutdown()
+
+	counted := make(chan *session.Session, 1)
+	var games []*cluster.Node
+	for i := 0; i < 2; i++ {
+		gameComps := &component.Components{}
+		gameComps.Register(&CountComponent{counted: counted})
+		gameNode := startNode(c, cluster.Options{
+			AdvertiseAddr: masterNode.ServiceAddr,
+			Components:    gameComps,
+		})
+		defer gameNode.Shutdown()
+		games = append(games, gameNode)
+	}
+
+	gateNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		ClientAddr:    "127.0.0.1:0",
+	})
+	defer gateNode.Shutdown()
+
+	cli, err := client.Dial(context.Background(), gateNode.ClientAddr)
+	c.Assert(err, IsNil)
+	defer cli.Close()
+	count := func() string {
+		pong := &testdata.Pong{}
+		c.Assert(cli.Request(context.Background(), "CountComponent.Count", &testdata.Ping{}, pong), IsNil)
+		return pong.Content
+	}
+	c.Assert(count(), Equals, "42 1")
+	c.Assert(count(), Equals, "42 2")
+	sess := <-counted
+
+	source, target := games[0], games[1]
+	if source.Load().Sessions == 0 {
+		source, target = target, source
+	}
+	c.Assert(source.MigrateSession(context.Background(), sess, target.ServiceAddr), IsNil)
+	c.Assert(source.Load().Sessions, Equals, int64(0))
+	c.Assert(target.Load().Sessions, Equals, int64(1))
+
+	// The state and the binding are migrated to target
+	c.Assert(count(), Equals, "42 3")
+	c.Assert(source.Load().Sessions, Equals, int64(0))
+
+	// The message following the migration is served by the member which the
+	// session migrated to, wherever it arrives
+	c.Assert(cli.Notify("CountComponent.Migrate", &testdata.Ping{Content: source.ServiceAddr}), IsNil)
+	c.Assert(count(), Equals, "42 4")
+	c.Assert(source.Load().Sessions, Equals, int64(1))
+	c.Assert(target.Load().Sessions, Equals, int64(0))
+
+	// The session is still served by current member if the gate fails to
+	// rebind it, and the member which restored it drops it
+	pong := &testdata.Pong{}
+	c.Assert(cli.Request(context.Background(), "CountComponent.FailRebind", &testdata.Ping{}, pong), IsNil)
+	c.Assert(cli.Notify("CountComponent.Migrate", &testdata.Ping{Content: target.ServiceAddr}), IsNil)
+	c.Assert(count(), Equals, "42 5")
+	c.Assert(source.Load().Sessions, Equals, int64(1))
+	c.Assert(target.Load().Sessions, Equals, int64(0))
+	buf := &bytes.Buffer{}
+	c.Assert(source.Metrics().Write(buf), IsNil)
+	c.Assert(buf.String(), Matches, `(?s).*\nnano_rebind_failures_total 1\n.*`)
+
+	err = source.MigrateSession(context.Background(), session.New(nil), target.ServiceAddr)
+	c.Assert(err, Equals, cluster.ErrSessionNotForwarded)
+}
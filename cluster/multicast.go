@@ -0,0 +1,152 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/lonng/nano/cluster/clusterpb"
+	"github.com/lonng/nano/internal/log"
+	"github.com/lonng/nano/internal/message"
+	"github.com/lonng/nano/session"
+)
+
+// SessionGate returns the service address of the gate which the session connected
+// to, or an empty string if the session connected to the current node
+func SessionGate(s *session.Session) string {
+	if a, ok := s.NetworkEntity().(*acceptor); ok {
+		return a.gateAddr
+	}
+	return ""
+}
+
+// Multicast pushes the serialized message to the sessions. The sessions connected
+// to the current node are pushed one by one, and the sessions connected to the same
+// remote gate are pushed in a single RPC, which the gate delivers to its local
+// sessions, so that broadcasting to a large group costs one RPC per gate rather
+// than one per session. A *MulticastError is returned if some pushes failed.
+func Multicast(sessions []*session.Session, route string, data []byte) error {
+	type batch struct {
+		client  clusterpb.MemberClient
+		batcher *batcher
+		stream  *stream
+		ids     []int64
+	}
+	var gates []string
+	batches := map[string]*batch{}
+
+	var errs []error
+	for _, s := range sessions {
+		a, ok := s.NetworkEntity().(*acceptor)
+		if !ok {
+			if err := s.Push(route, data); err != nil {
+				log.Println(fmt.Sprintf("Session push message error, ID=%d, UID=%d, Error=%s", s.ID(), s.UID(), err.Error()))
+				errs = append(errs, err)
+			}
+			continue
+		}
+		b, found := batches[a.gateAddr]
+		if !found {
+			b = &batch{client: a.gateClient, batcher: a.batcher, stream: a.stream()}
+			batches[a.gateAddr] = b
+			gates = append(gates, a.gateAddr)
+		}
+		b.ids = append(b.ids, a.sid)
+	}
+
+	for _, gate := range gates {
+		b := batches[gate]
+		request := &clusterpb.MulticastMessage{
+			SessionIds: b.ids,
+			Route:      route,
+			Data:       data,
+		}
+		if b.stream != nil {
+			if b.batcher != nil {
+				b.batcher.flush()
+			}
+			if err := b.stream.Send(&clusterpb.StreamMessage{Multicast: request}); err != nil {
+				log.Println(fmt.Sprintf("Multicast message to gate %s error: %s", gate, err.Error()))
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if b.batcher != nil && b.batcher.add(&clusterpb.BatchEntry{Multicast: request}, len(route)+len(data)+8*len(b.ids)) {
+			continue
+		}
+		if _, err := b.client.HandleMulticast(context.Background(), request); err != nil {
+			log.Println(fmt.Sprintf("Multicast message to gate %s error: %s", gate, err.Error()))
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return &MulticastError{Errors: errs}
+	}
+	return nil
+}
+
+// MulticastValue is like Multicast, but v is serialized by the serializer of the
+// node which the sessions belong to, see Options.Serializer. Nothing is pushed if
+// v fails to be serialized, and the serialization error is returned.
+func MulticastValue(sessions []*session.Session, route string, v interface{}) error {
+	var nodes []*Node
+	groups := map[*Node][]*session.Session{}
+	for _, s := range sessions {
+		var n *Node
+		switch e := s.NetworkEntity().(type) {
+		case *agent:
+			n = e.node
+		case *acceptor:
+			n = e.node
+		}
+		if _, found := groups[n]; !found {
+			nodes = append(nodes, n)
+		}
+		groups[n] = append(groups[n], s)
+	}
+
+	payloads := map[*Node][]byte{}
+	for _, n := range nodes {
+		var data []byte
+		var err error
+		if n == nil {
+			data, err = message.Serialize(v)
+		} else {
+			data, err = n.serialize(v)
+		}
+		if err != nil {
+			return err
+		}
+		payloads[n] = data
+	}
+
+	var errs []error
+	for _, n := range nodes {
+		if err := Multicast(groups[n], route, payloads[n]); err != nil {
+			errs = append(errs, err.(*MulticastError).Errors...)
+		}
+	}
+	if len(errs) > 0 {
+		return &MulticastError{Errors: errs}
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+package cluster_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/lonng/nano/benchmark/testdata"
+	"github.com/lonng/nano/client"
+	"github.com/lonng/nano/cluster"
+	"github.com/lonng/nano/component"
+	"github.com/lonng/nano/serialize/protobuf"
+	"github.com/lonng/nano/session"
+	. "github.com/pingcap/check"
+)
+
+var joined = make(chan *session.Session, 2)
+
+func (c *GameComponent) Join(s *session.Session, _ *testdata.Ping) error {
+	joined <- s
+	return s.Response(&testdata.Pong{Content: "joined"})
+}
+
+func (s *nodeSuite) TestMulticast(c *C) {
+	masterNode := startNode(c, cluster.Options{IsMaster: true})
+	defer masterNode.Shutdown()
+
+	gateNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		ClientAddr:    "127.0.0.1:0",
+	})
+	defer gateNode.Shutdown()
+
+	closed := make(chan int64, 1)
+	lifetime := session.NewLifetime()
+	lifetime.OnClosed(func(s *session.Session) { closed <- s.ID() })
+	gameComps := &component.Components{}
+	gameComps.Register(&GameComponent{})
+	gameNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		Components:    gameComps,
+		Lifetime:      lifetime,
+	})
+	defer gameNode.Shutdown()
+
+	pushed := make(chan string, 2)
+	var clients []*client.Client
+	var sessions []*session.Session
+	for i := 0; i < 2; i++ {
+		cli, err := client.Dial(context.Background(), gateNode.ClientAddr)
+		c.Assert(err, IsNil)
+		defer cli.Close()
+		cli.On("onMulticast", func(data []byte) {
+			pong := &testdata.Pong{}
+			c.Assert(cli.Unmarshal(data, pong), IsNil)
+			pushed <- pong.Content
+		})
+		c.Assert(cli.Request(context.Background(), "GameComponent.Join", &testdata.Ping{Content: "join"}, &testdata.Pong{}), IsNil)
+		s := <-joined
+		c.Assert(cluster.SessionGate(s), Equals, gateNode.ServiceAddr)
+		clients = append(clients, cli)
+		sessions = append(sessions, s)
+	}
+
+	data, err := protobuf.NewSerializer().Marshal(&testdata.Pong{Content: "multicast"})
+	c.Assert(err, IsNil)
+	c.Assert(cluster.Multicast(sessions, "onMulticast", data), IsNil)
+	for i := 0; i < 2; i++ {
+		select {
+		case content := <-pushed:
+			c.Assert(content, Equals, "multicast")
+		case <-time.After(time.Second):
+			c.Fatal("multicast not received")
+		}
+	}
+
+	// The closed sessions are ignored by the gate
+	c.Assert(clients[0].Close(), IsNil)
+	select {
+	case id := <-closed:
+		c.Assert(id, Equals, sessions[0].ID())
+	case <-time.After(time.Second):
+		c.Fatal("session not closed")
+	}
+	c.Assert(cluster.Multicast(sessions, "onMulticast", data), IsNil)
+	select {
+	case content := <-pushed:
+		c.Assert(content, Equals, "multicast")
+	case <-time.After(time.Second):
+		c.Fatal("multicast not received")
+	}
+	select {
+	case <-pushed:
+		c.Fatal("multicast received by the closed session")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
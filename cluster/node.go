@@ -22,12 +22,15 @@ package cluster
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/gorilla/websocket"
@@ -36,10 +39,16 @@ import (
 	"github.com/lonng/nano/internal/env"
 	"github.com/lonng/nano/internal/log"
 	"github.com/lonng/nano/internal/message"
+	"github.com/lonng/nano/metrics"
 	"github.com/lonng/nano/pipeline"
 	"github.com/lonng/nano/scheduler"
+	"github.com/lonng/nano/serialize"
+	"github.com/lonng/nano/service"
 	"github.com/lonng/nano/session"
+	"github.com/lonng/nano/tracing"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 // Options contains some configurations for current node
@@ -47,7 +56,17 @@ type Options struct {
 	Pipeline           pipeline.Pipeline
 	IsMaster           bool
 	AdvertiseAddr      string
+	AdvertiseAddrs     []string
+	Discovery          Discovery
 	RetryInterval      time.Duration
+	ElectionTimeout    time.Duration
+	ResumeWindow       time.Duration
+	RequestTimeout     time.Duration
+	MetricsAddr        string
+	TraceExporter      tracing.Exporter
+	BatchInterval      time.Duration
+	BatchSize          int
+	ForwardStream      bool
 	ClientAddr         string
 	Components         *component.Components
 	Label              string
@@ -56,6 +75,29 @@ type Options struct {
 	TSLKey             string
 	UnregisterCallback func(Member)
 	RemoteServiceRoute CustomerRemoteServiceRoute
+	Balancer           Balancer                     // balancer of the services without a specified one
+	Balancers          map[string]Balancer          // balancers indexed by service
+	Weight             int                          // weight advertised to the weighted balancers
+	LoadGauges         map[string]func() float64    // custom gauges reported in the load
+	SessionHandoff     func(*session.Session) error // hands off the session state while draining
+	DrainTimeout       time.Duration                // drains the node before shutdown if positive
+	StateSerializer    StateSerializer              // serializes the migrated session state
+	ForwardedValues    []string                     // session values forwarded by the gates to the backends
+	ActorWorkers       int                          // workers of the actors, the number of CPUs by default
+
+	// The following options are scoped to current node, and fall back to the
+	// process wide defaults if not specified
+	Heartbeat          time.Duration                        // heartbeat interval
+	CheckOrigin        func(*http.Request) bool             // checks the origin of the WebSocket requests
+	Debug              bool                                 // enables the debug logs
+	WSPath             string                               // path of the WebSocket endpoint
+	HandshakeValidator func(*session.Session, []byte) error // validates the handshake data
+	Serializer         serialize.Serializer                 // serializes the payloads of the handlers
+	GrpcOptions        []grpc.DialOption                    // dial options of the members
+	Dictionary         map[string]uint16                    // compressed routes
+	NodeID             uint64                               // scopes the session ids to current node if not zero
+	Scheduler          *scheduler.Scheduler                 // runs the handlers, scheduler.Default() by default
+	Lifetime           *session.LifetimeManager             // callbacks for the sessions of current node, session.Lifetime by default
 }
 
 // Node represents a node in nano cluster, which will contains a group of services.
@@ -70,122 +112,282 @@ type Node struct {
 	server    *grpc.Server
 	rpcClient *rpcClient
 
-	mu       sync.RWMutex
-	sessions map[int64]*session.Session
+	mu         sync.RWMutex
+	sessions   map[int64]*session.Session
+	moving     map[int64]chan struct{}          // closed once the sessions moved to another member
+	batchers   map[string]*batcher              // batchers indexed by gate address
+	loads      map[string]*clusterpb.MemberLoad // loads of the members indexed by address
+	cpu        cpuSampler
+	conns      service.Connection     // generates the session ids and counts the connections
+	dict       *message.Dictionary    // compresses the routes
+	die        chan struct{}          // closed once current node shutdown
+	actors     *scheduler.ActorPool   // actors of the services scheduled by session or key
+	scheds     []*scheduler.Scheduler // named schedulers of the services
+	draining   int32                  // 1 if current node is draining
+	listener   net.Listener           // listener of the clients
+	stopped    bool                   // whether current node stopped accepting the clients
+	registered bool                   // whether current node registered to the cluster
+
+	streamMu       sync.Mutex // serializes opening the streams
+	backendStreams streams    // streams opened to the backend members
+	gateStreams    streams    // streams opened by the gates
 
 	once          sync.Once
+	shutdownOnce  sync.Once // shuts down current node once
+	stopOnce      sync.Once // releases the resources once
 	keepaliveExit chan struct{}
+	masterIndex   uint32
+
+	discovery Discovery
+	stopWatch context.CancelFunc
+
+	metrics       *nodeMetrics // built-in metrics of current node
+	metricsServer *http.Server
 }
 
+// Startup starts current node, see StartupContext.
 func (n *Node) Startup() error {
+	return n.StartupContext(context.Background())
+}
+
+// StartupContext starts current node and returns once the listeners are bound
+// and current node registered to the cluster, the ports 0 of the addresses are
+// replaced by the ports bound. Registering is retried until ctx is done, and
+// the resources are released if current node failed to start up.
+func (n *Node) StartupContext(ctx context.Context) error {
 	if n.ServiceAddr == "" {
 		return errors.New("service address cannot be empty in master node")
 	}
+	if err := n.startup(ctx); err != nil {
+		n.stop()
+		return err
+	}
+
+	// Initialize all components
+	components := n.Components.List()
+	for _, c := range components {
+		c.Comp.Init()
+	}
+	for _, c := range components {
+		c.Comp.AfterInit()
+	}
+
+	n.mu.RLock()
+	listener := n.listener
+	n.mu.RUnlock()
+	if listener != nil {
+		if n.IsWebsocket {
+			go n.serveWS(listener)
+		} else {
+			go n.serve(listener)
+		}
+	}
+	return nil
+}
+
+// startup prepares current node and binds the listeners
+func (n *Node) startup(ctx context.Context) error {
+	n.applyDefaults()
 	n.sessions = map[int64]*session.Session{}
+	n.moving = map[int64]chan struct{}{}
+	n.die = make(chan struct{})
+	n.actors = scheduler.NewActorPool(n.ActorWorkers)
+	n.metrics = newNodeMetrics(n)
 	n.cluster = newCluster(n)
 	n.handler = NewHandler(n, n.Pipeline)
-	components := n.Components.List()
-	for _, c := range components {
+	for _, c := range n.Components.List() {
 		err := n.handler.register(c.Comp, c.Opts)
 		if err != nil {
 			return err
 		}
 	}
 
-	cache()
-	if err := n.initNode(); err != nil {
-		return err
+	n.startSchedulers()
+	n.handler.cache()
+
+	if n.MetricsAddr != "" {
+		if err := n.listenAndServeMetrics(); err != nil {
+			return err
+		}
+	}
+	if n.ClientAddr != "" {
+		if err := n.listen(); err != nil {
+			return err
+		}
 	}
 
-	// Initialize all components
-	for _, c := range components {
-		c.Comp.Init()
+	return n.initNode(ctx)
+}
+
+// applyDefaults applies the process wide defaults to the options not specified
+func (n *Node) applyDefaults() {
+	if n.Heartbeat <= 0 {
+		n.Heartbeat = env.Heartbeat
 	}
-	for _, c := range components {
-		c.Comp.AfterInit()
+	if n.CheckOrigin == nil {
+		n.CheckOrigin = env.CheckOrigin
+	}
+	n.Debug = n.Debug || env.Debug
+	if n.WSPath == "" {
+		n.WSPath = env.WSPath
+	}
+	if n.HandshakeValidator == nil {
+		n.HandshakeValidator = env.HandshakeValidator
+	}
+	if n.Serializer == nil {
+		n.Serializer = env.Serializer
+	}
+	if n.Scheduler == nil {
+		n.Scheduler = scheduler.Default()
+	}
+	if n.Lifetime == nil {
+		n.Lifetime = session.Lifetime
 	}
 
-	if n.ClientAddr != "" {
-		go func() {
-			if n.IsWebsocket {
-				if len(n.TSLCertificate) != 0 {
-					n.listenAndServeWSTLS()
-				} else {
-					n.listenAndServeWS()
-				}
-			} else {
-				n.listenAndServe()
-			}
-		}()
+	if len(n.Dictionary) == 0 {
+		n.Dictionary, _ = message.GetDictionary()
 	}
+	n.dict = message.NewDictionary(n.Dictionary)
 
-	return nil
+	n.conns = &connections{Connection: service.Connections}
+	if n.NodeID != 0 {
+		n.conns = &connections{Connection: service.NewConnection(n.NodeID)}
+	}
+}
+
+// connections counts the connections of a node, and generates the session ids
+// via the connection service, which is shared by the nodes without NodeID. The
+// connections are counted by service.Connections as well, which counts the ones
+// of all nodes in the process.
+type connections struct {
+	service.Connection
+	count int64
+}
+
+func (c *connections) Increment() {
+	atomic.AddInt64(&c.count, 1)
+	service.Connections.Increment()
+}
+
+func (c *connections) Decrement() {
+	atomic.AddInt64(&c.count, -1)
+	service.Connections.Decrement()
+}
+
+func (c *connections) Count() int64 {
+	return atomic.LoadInt64(&c.count)
+}
+
+func (c *connections) Reset() {
+	atomic.StoreInt64(&c.count, 0)
+}
+
+// newSession returns a new session of current node, which replicates the
+// forwarded values of current node
+func (n *Node) newSession(entity session.NetworkEntity) *session.Session {
+	s := session.NewWithID(n.conns.SessionID(), entity)
+	if len(n.ForwardedValues) > 0 {
+		s.Replicate(n.ForwardedValues...)
+	}
+	return s
+}
+
+// closeSession calls the lifetime callbacks of the closed session on the scheduler
+func (n *Node) closeSession(s *session.Session) {
+	n.Scheduler.PushTask(func() {
+		n.Lifetime.Close(s)
+	})
+}
+
+// serialize marshals v by the serializer of current node
+func (n *Node) serialize(v interface{}) ([]byte, error) {
+	return message.SerializeWith(n.Serializer, v)
+}
+
+// startSchedulers starts the named schedulers the services bound to
+func (n *Node) startSchedulers() {
+	started := map[string]bool{}
+	for _, s := range n.handler.localServices {
+		if s.Scheduler == "" || started[s.Scheduler] {
+			continue
+		}
+		started[s.Scheduler] = true
+		// The named scheduler is shared by the nodes in current process
+		sched := scheduler.Named(s.Scheduler)
+		sched.Acquire()
+		n.mu.Lock()
+		n.scheds = append(n.scheds, sched)
+		n.mu.Unlock()
+	}
 }
 
 func (n *Node) Handler() *LocalHandler {
 	return n.handler
 }
 
-func (n *Node) initNode() error {
+func (n *Node) initNode(ctx context.Context) error {
 	// Current node is not master server and does not contains master
 	// address, so running in singleton mode
-	if !n.IsMaster && n.AdvertiseAddr == "" {
+	if !n.IsMaster && len(n.masterAddrs()) == 0 && n.Discovery == nil {
 		return nil
 	}
+	if n.ElectionTimeout == 0 {
+		n.ElectionTimeout = 5 * time.Second
+	}
 
 	listener, err := net.Listen("tcp", n.ServiceAddr)
 	if err != nil {
 		return err
 	}
+	n.ServiceAddr = boundAddr(n.ServiceAddr, listener)
 
 	// Initialize the gRPC server and register service
 	n.server = grpc.NewServer()
-	n.rpcClient = newRPCClient()
+	n.rpcClient = newRPCClient(append(append([]grpc.DialOption{}, env.GrpcOptions...), n.GrpcOptions...))
 	clusterpb.RegisterMemberServer(n.server, n)
 
 	go func() {
 		err := n.server.Serve(listener)
-		if err != nil {
-			log.Fatalf("Start current node failed: %v", err)
+		if err != nil && err != grpc.ErrServerStopped {
+			log.Println("Serve current node failed", err)
 		}
 	}()
 
 	if n.IsMaster {
 		clusterpb.RegisterMasterServer(n.server, n.cluster)
 		member := &Member{
-			isMaster: true,
-			memberInfo: &clusterpb.MemberInfo{
-				Label:       n.Label,
-				ServiceAddr: n.ServiceAddr,
-				Services:    n.handler.LocalService(),
-			},
+			isMaster:   true,
+			memberInfo: n.memberInfo(),
 		}
 		n.cluster.members = append(n.cluster.members, member)
 		n.cluster.setRpcClient(n.rpcClient)
+		n.cluster.elect()
 	} else {
-		pool, err := n.rpcClient.getConnPool(n.AdvertiseAddr)
-		if err != nil {
-			return err
+		n.discovery = n.Discovery
+		if n.discovery == nil {
+			n.discovery = newMasterDiscovery(n)
 		}
-		client := clusterpb.NewMasterClient(pool.Get())
-		request := &clusterpb.RegisterRequest{
-			MemberInfo: &clusterpb.MemberInfo{
-				Label:       n.Label,
-				ServiceAddr: n.ServiceAddr,
-				Services:    n.handler.LocalService(),
-			},
+		watchCtx, cancel := context.WithCancel(context.Background())
+		n.stopWatch = cancel
+		if err := n.discovery.Watch(watchCtx, n.applyMemberEvent); err != nil {
+			return err
 		}
 		for {
-			resp, err := client.Register(context.Background(), request)
+			members, err := n.discovery.Register(ctx, n.memberInfo())
 			if err == nil {
-				n.handler.initRemoteService(resp.Members)
-				n.cluster.initMembers(resp.Members)
+				n.registered = true
+				for _, m := range members {
+					n.applyMemberEvent(MemberEvent{Type: MemberAdded, Member: m})
+				}
 				break
 			}
 			log.Println("Register current node to cluster failed", err, "and will retry in", n.RetryInterval.String())
-			time.Sleep(n.RetryInterval)
+			select {
+			case <-time.After(n.RetryInterval):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
 		}
-		n.once.Do(n.keepalive)
 	}
 	return nil
 }
@@ -193,6 +395,10 @@ func (n *Node) initNode() error {
 // Shutdowns all components registered by application, that
 // call by reverse order against register
 func (n *Node) Shutdown() {
+	n.shutdownOnce.Do(n.shutdown)
+}
+
+func (n *Node) shutdown() {
 	// reverse call `BeforeShutdown` hooks
 	components := n.Components.List()
 	length := len(components)
@@ -204,82 +410,185 @@ func (n *Node) Shutdown() {
 	for i := length - 1; i >= 0; i-- {
 		components[i].Comp.Shutdown()
 	}
+	n.stop()
+}
+
+// stop stops the services of current node and releases the resources, which
+// could be partially initialized if current node failed to start up. It could
+// be called again once shut down after failing to start up.
+func (n *Node) stop() {
+	n.stopOnce.Do(n.release)
+}
+
+func (n *Node) release() {
 	// close sendHeartbeat
 	if n.keepaliveExit != nil {
 		close(n.keepaliveExit)
 	}
-	if !n.IsMaster && n.AdvertiseAddr != "" {
-		pool, err := n.rpcClient.getConnPool(n.AdvertiseAddr)
-		if err != nil {
-			log.Println("Retrieve master address error", err)
-			goto EXIT
-		}
-		client := clusterpb.NewMasterClient(pool.Get())
-		request := &clusterpb.UnregisterRequest{
-			ServiceAddr: n.ServiceAddr,
-		}
-		_, err = client.Unregister(context.Background(), request)
-		if err != nil {
+	if n.IsMaster && n.cluster != nil {
+		close(n.cluster.die)
+	}
+	if n.stopWatch != nil {
+		n.stopWatch()
+	}
+	if n.registered {
+		if err := n.discovery.Deregister(context.Background(), n.memberInfo()); err != nil {
 			log.Println("Unregister current node failed", err)
-			goto EXIT
 		}
 	}
 
-EXIT:
+	n.stopAccept()
+	if n.handler != nil {
+		n.handler.closeParked()
+	}
+	n.backendStreams.closeAll()
+	n.gateStreams.closeAll()
+
+	n.mu.Lock()
+	for _, b := range n.batchers {
+		b.close()
+	}
+	n.batchers = nil
+	n.mu.Unlock()
+
 	if n.server != nil {
 		n.server.GracefulStop()
 	}
+	if n.metricsServer != nil {
+		n.metricsServer.Close()
+	}
+	if n.actors != nil {
+		n.actors.Close()
+	}
+	if n.die != nil {
+		close(n.die)
+	}
+	n.mu.Lock()
+	scheds := n.scheds
+	n.scheds = nil
+	n.mu.Unlock()
+	for _, s := range scheds {
+		s.Release()
+	}
 }
 
-// Enable current server accept connection
-func (n *Node) listenAndServe() {
-	listener, err := net.Listen("tcp", n.ClientAddr)
+// Call sends a request to the handler of route on the node which provides the
+// service and waits for the response, see the package level Call.
+func (n *Node) Call(ctx context.Context, route string, v, reply interface{}) error {
+	return n.handler.call(ctx, nil, route, v, reply)
+}
+
+// Metrics returns the registry of the built-in metrics of current node once
+// started, which contains metrics.DefaultRegistry, e.g. to be exposed by the
+// application. It returns nil if current node has not started.
+func (n *Node) Metrics() *metrics.Registry {
+	if n.metrics == nil {
+		return nil
+	}
+	return n.metrics.registry
+}
+
+// listenAndServeMetrics exposes the metrics in the text exposition format on
+// the /metrics endpoint
+func (n *Node) listenAndServeMetrics() error {
+	listener, err := net.Listen("tcp", n.MetricsAddr)
 	if err != nil {
-		log.Fatal(err.Error())
+		return err
 	}
+	n.MetricsAddr = boundAddr(n.MetricsAddr, listener)
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", n.metrics.registry.Handler())
+	n.metricsServer = &http.Server{Handler: mux}
+	go func() {
+		if err := n.metricsServer.Serve(listener); err != nil && err != http.ErrServerClosed {
+			log.Println("Serve metrics failed", err)
+		}
+	}()
+	return nil
+}
 
-	defer listener.Close()
-	for {
-		conn, err := listener.Accept()
+// listen listens on the client address, the WebSocket clients are served over
+// TLS if the certificate specified
+func (n *Node) listen() error {
+	listener, err := net.Listen("tcp", n.ClientAddr)
+	if err != nil {
+		return err
+	}
+	if n.IsWebsocket && len(n.TSLCertificate) != 0 {
+		cert, err := tls.LoadX509KeyPair(n.TSLCertificate, n.TSLKey)
 		if err != nil {
-			log.Println(err.Error())
-			continue
+			listener.Close()
+			return err
 		}
+		listener = tls.NewListener(listener, &tls.Config{Certificates: []tls.Certificate{cert}})
+	}
+	n.ClientAddr = boundAddr(n.ClientAddr, listener)
 
-		go n.handler.handle(conn)
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.listener = listener
+	return nil
+}
+
+// boundAddr replaces the port of addr with the one listener bound to, e.g. the
+// port chosen by the system for port 0
+func boundAddr(addr string, listener net.Listener) string {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return addr
+	}
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	if err != nil {
+		return addr
 	}
+	return net.JoinHostPort(host, port)
 }
 
-func (n *Node) listenAndServeWS() {
-	var upgrader = websocket.Upgrader{
-		ReadBufferSize:  1024,
-		WriteBufferSize: 1024,
-		CheckOrigin:     env.CheckOrigin,
+// stopAccept stops accepting the clients
+func (n *Node) stopAccept() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.stopped = true
+	if n.listener != nil {
+		n.listener.Close()
+		n.listener = nil
 	}
+}
 
-	http.HandleFunc("/"+strings.TrimPrefix(env.WSPath, "/"), func(w http.ResponseWriter, r *http.Request) {
-		conn, err := upgrader.Upgrade(w, r, nil)
+// accepting returns whether current node is accepting the clients
+func (n *Node) accepting() bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return !n.stopped
+}
+
+// serve accepts the clients until current node stops accepting
+func (n *Node) serve(listener net.Listener) {
+	defer listener.Close()
+	for {
+		conn, err := listener.Accept()
 		if err != nil {
-			log.Println(fmt.Sprintf("Upgrade failure, URI=%s, Error=%s", r.RequestURI, err.Error()))
-			return
+			if !n.accepting() {
+				return
+			}
+			log.Println(err.Error())
+			continue
 		}
 
-		n.handler.handleWS(conn)
-	})
-
-	if err := http.ListenAndServe(n.ClientAddr, nil); err != nil {
-		log.Fatal(err.Error())
+		go n.handler.handle(conn)
 	}
 }
 
-func (n *Node) listenAndServeWSTLS() {
+// wsMux returns the handler which upgrades the WebSocket requests
+func (n *Node) wsMux() *http.ServeMux {
 	var upgrader = websocket.Upgrader{
 		ReadBufferSize:  1024,
 		WriteBufferSize: 1024,
-		CheckOrigin:     env.CheckOrigin,
+		CheckOrigin:     n.CheckOrigin,
 	}
 
-	http.HandleFunc("/"+strings.TrimPrefix(env.WSPath, "/"), func(w http.ResponseWriter, r *http.Request) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/"+strings.TrimPrefix(n.WSPath, "/"), func(w http.ResponseWriter, r *http.Request) {
 		conn, err := upgrader.Upgrade(w, r, nil)
 		if err != nil {
 			log.Println(fmt.Sprintf("Upgrade failure, URI=%s, Error=%s", r.RequestURI, err.Error()))
@@ -288,9 +597,13 @@ func (n *Node) listenAndServeWSTLS() {
 
 		n.handler.handleWS(conn)
 	})
+	return mux
+}
 
-	if err := http.ListenAndServeTLS(n.ClientAddr, n.TSLCertificate, n.TSLKey, nil); err != nil {
-		log.Fatal(err.Error())
+// serveWS serves the WebSocket clients until current node stops accepting
+func (n *Node) serveWS(listener net.Listener) {
+	if err := http.Serve(listener, n.wsMux()); err != nil && n.accepting() {
+		log.Println("Serve WebSocket clients failed", err)
 	}
 }
 
@@ -300,6 +613,21 @@ func (n *Node) storeSession(s *session.Session) {
 	n.mu.Unlock()
 }
 
+func (n *Node) removeSession(sid int64) {
+	n.mu.Lock()
+	delete(n.sessions, sid)
+	n.mu.Unlock()
+}
+
+// unbindSessions drops the bindings of the sessions to the member which left
+func (n *Node) unbindSessions(addr string) {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	for _, s := range n.sessions {
+		s.Router().Unbind(addr)
+	}
+}
+
 func (n *Node) findSession(sid int64) *session.Session {
 	n.mu.RLock()
 	s := n.sessions[sid]
@@ -316,13 +644,20 @@ func (n *Node) findOrCreateSession(sid int64, gateAddr string) (*session.Session
 		if err != nil {
 			return nil, err
 		}
+		gateClient := clusterpb.NewMemberClient(conns.Get())
 		ac := &acceptor{
-			sid:        sid,
-			gateClient: clusterpb.NewMemberClient(conns.Get()),
-			rpcHandler: n.handler.remoteProcess,
-			gateAddr:   gateAddr,
+			node:        n,
+			sid:         sid,
+			gateClient:  gateClient,
+			rpcHandler:  n.handler.remoteProcess,
+			callHandler: n.handler.call,
+			calls:       n.handler.calls,
+			gateAddr:    gateAddr,
+			batcher:     n.batcher(gateAddr, gateClient),
+			streams:     &n.gateStreams,
 		}
-		s = session.New(ac)
+		ac.ctx, ac.cancel = context.WithCancel(context.Background())
+		s = n.newSession(ac)
 		ac.session = s
 		n.mu.Lock()
 		n.sessions[sid] = s
@@ -331,40 +666,201 @@ func (n *Node) findOrCreateSession(sid int64, gateAddr string) (*session.Session
 	return s, nil
 }
 
-func (n *Node) HandleRequest(_ context.Context, req *clusterpb.RequestMessage) (*clusterpb.MemberHandleResponse, error) {
+// forwardedSession returns the session forwarded from the gate. The messages of
+// a session moving to another member wait until the gate rebinds the session,
+// then are rejected to be forwarded again by the gate. The wait is bounded by
+// the request timeout if ctx has no deadline
+func (n *Node) forwardedSession(ctx context.Context, sid int64, gateAddr string) (*session.Session, error) {
+	n.mu.Lock()
+	moved, moving := n.moving[sid]
+	if moving {
+		select {
+		case <-moved:
+			// The message was sent before the gate rebound the session
+			delete(n.moving, sid)
+			n.mu.Unlock()
+			return nil, status.Error(codes.Aborted, ErrSessionMoved.Error())
+		default:
+		}
+	}
+	n.mu.Unlock()
+	if !moving {
+		return n.findOrCreateSession(sid, gateAddr)
+	}
+
+	// The stream carries no deadline of the notifications
+	if _, ok := ctx.Deadline(); !ok && n.RequestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, n.RequestTimeout)
+		defer cancel()
+	}
+	select {
+	case <-moved:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	// The session is still served by current node if the move failed
+	if s := n.findSession(sid); s != nil {
+		return s, nil
+	}
+	return nil, status.Error(codes.Aborted, ErrSessionMoved.Error())
+}
+
+// beginMove marks the session moving to another member, the messages of the
+// session wait until the move ends. It returns nil if the session is moving
+func (n *Node) beginMove(sid int64) chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if moved, moving := n.moving[sid]; moving {
+		select {
+		case <-moved:
+		default:
+			return nil
+		}
+	}
+	moved := make(chan struct{})
+	n.moving[sid] = moved
+	return moved
+}
+
+// endMove ends moving the session s, which is forgotten by current node if the
+// gate rebound it, otherwise current node keeps serving it. It returns whether
+// s is forgotten, which is false if the gate closed s meanwhile
+func (n *Node) endMove(sid int64, s *session.Session, moved chan struct{}, rebound bool) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	defer close(moved)
+	if !rebound {
+		delete(n.moving, sid)
+		return false
+	}
+	if n.sessions[sid] != s {
+		return false
+	}
+	delete(n.sessions, sid)
+	return true
+}
+
+// updateSession updates the session with the UID and the replicated values
+// forwarded from the gate
+func (n *Node) updateSession(s *session.Session, uid int64, values map[string][]byte) error {
+	if uid > 0 && uid != s.UID() {
+		// The UID is known by the gate, which needs not be propagated back
+		if ac, ok := s.NetworkEntity().(*acceptor); ok {
+			atomic.StoreInt64(&ac.uid, uid)
+		}
+		if err := s.Bind(uid); err != nil {
+			return err
+		}
+	}
+	return s.SetReplicatedValues(values)
+}
+
+// batcher returns the batcher of the gate, or nil if the batching is disabled
+func (n *Node) batcher(gateAddr string, client clusterpb.MemberClient) *batcher {
+	if n.BatchInterval <= 0 {
+		return nil
+	}
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.batchers == nil {
+		n.batchers = map[string]*batcher{}
+	}
+	b, found := n.batchers[gateAddr]
+	if !found {
+		b = newBatcher(gateAddr, client, n.BatchInterval, n.BatchSize, n.metrics.batchFailures)
+		n.batchers[gateAddr] = b
+	}
+	return b
+}
+
+// closeBatcher flushes and removes the batcher of the gate which left
+func (n *Node) closeBatcher(gateAddr string) {
+	n.mu.Lock()
+	b, found := n.batchers[gateAddr]
+	delete(n.batchers, gateAddr)
+	n.mu.Unlock()
+	if found {
+		b.close()
+	}
+}
+
+func (n *Node) HandleRequest(ctx context.Context, req *clusterpb.RequestMessage) (*clusterpb.MemberHandleResponse, error) {
 	handler, found := n.handler.localHandlers[req.Route]
 	if !found {
 		return nil, fmt.Errorf("service not found in current node: %v", req.Route)
 	}
-	s, err := n.findOrCreateSession(req.SessionId, req.GateAddr)
+	s, err := n.forwardedSession(ctx, req.SessionId, req.GateAddr)
 	if err != nil {
 		return nil, err
 	}
+	if err := n.updateSession(s, req.Uid, req.Values); err != nil {
+		return nil, err
+	}
 	msg := &message.Message{
 		Type:  message.Request,
 		ID:    req.Id,
 		Route: req.Route,
 		Data:  req.Data,
 	}
-	n.handler.localProcess(handler, req.Id, s, msg)
+	ctx = tracing.ContextWithRemoteSpanContext(ctx, tracing.Extract(req.Metadata))
+	n.handler.localProcess(ctx, handler, req.Id, s, msg)
 	return &clusterpb.MemberHandleResponse{}, nil
 }
 
-func (n *Node) HandleNotify(_ context.Context, req *clusterpb.NotifyMessage) (*clusterpb.MemberHandleResponse, error) {
+func (n *Node) HandleCall(ctx context.Context, req *clusterpb.CallRequest) (*clusterpb.CallResponse, error) {
 	handler, found := n.handler.localHandlers[req.Route]
 	if !found {
 		return nil, fmt.Errorf("service not found in current node: %v", req.Route)
 	}
-	s, err := n.findOrCreateSession(req.SessionId, req.GateAddr)
+	var s *session.Session
+	if req.SessionId != 0 {
+		var err error
+		s, err = n.forwardedSession(ctx, req.SessionId, req.GateAddr)
+		if err != nil {
+			return nil, err
+		}
+		if err := n.updateSession(s, req.Uid, req.Values); err != nil {
+			return nil, err
+		}
+	}
+	ctx = tracing.ContextWithRemoteSpanContext(ctx, tracing.Extract(req.Metadata))
+	data, err := n.handler.localCall(ctx, handler, s, req.Route, req.Data)
+	if err == ErrCallTimeout || err == context.Canceled {
+		return nil, err
+	}
+	if err == ErrSessionMoved {
+		return nil, status.Error(codes.Aborted, err.Error())
+	}
+	resp := &clusterpb.CallResponse{Id: req.Id, Data: data}
+	if err != nil {
+		resp.Code, resp.Error = int32(errorCode(err)), err.Error()
+		if e, ok := err.(*message.Error); ok {
+			resp.Error = e.Message
+		}
+	}
+	return resp, nil
+}
+
+func (n *Node) HandleNotify(ctx context.Context, req *clusterpb.NotifyMessage) (*clusterpb.MemberHandleResponse, error) {
+	handler, found := n.handler.localHandlers[req.Route]
+	if !found {
+		return nil, fmt.Errorf("service not found in current node: %v", req.Route)
+	}
+	s, err := n.forwardedSession(ctx, req.SessionId, req.GateAddr)
 	if err != nil {
 		return nil, err
 	}
+	if err := n.updateSession(s, req.Uid, req.Values); err != nil {
+		return nil, err
+	}
 	msg := &message.Message{
 		Type:  message.Notify,
 		Route: req.Route,
 		Data:  req.Data,
 	}
-	n.handler.localProcess(handler, 0, s, msg)
+	ctx = tracing.ContextWithRemoteSpanContext(ctx, tracing.Extract(req.Metadata))
+	n.handler.localProcess(ctx, handler, 0, s, msg)
 	return &clusterpb.MemberHandleResponse{}, nil
 }
 
@@ -376,35 +872,112 @@ func (n *Node) HandlePush(_ context.Context, req *clusterpb.PushMessage) (*clust
 	return &clusterpb.MemberHandleResponse{}, s.Push(req.Route, req.Data)
 }
 
+// HandleMulticast pushes the message to the sessions of current gate, the sessions
+// which have been closed are ignored
+func (n *Node) HandleMulticast(_ context.Context, req *clusterpb.MulticastMessage) (*clusterpb.MemberHandleResponse, error) {
+	for _, sid := range req.SessionIds {
+		s := n.findSession(sid)
+		if s == nil {
+			continue
+		}
+		if err := s.Push(req.Route, req.Data); err != nil {
+			log.Println(fmt.Sprintf("Session push message error, ID=%d, UID=%d, Error=%s", s.ID(), s.UID(), err.Error()))
+		}
+	}
+	return &clusterpb.MemberHandleResponse{}, nil
+}
+
+// HandleBatch handles the pushes and responses coalesced by the backend in order
+func (n *Node) HandleBatch(ctx context.Context, req *clusterpb.BatchMessage) (*clusterpb.MemberHandleResponse, error) {
+	for _, entry := range req.Entries {
+		var err error
+		switch {
+		case entry.Push != nil:
+			_, err = n.HandlePush(ctx, entry.Push)
+		case entry.Response != nil:
+			_, err = n.HandleResponse(ctx, entry.Response)
+		case entry.Multicast != nil:
+			_, err = n.HandleMulticast(ctx, entry.Multicast)
+		}
+		if err != nil {
+			log.Println("Handle batched message error", err)
+		}
+	}
+	return &clusterpb.MemberHandleResponse{}, nil
+}
+
 func (n *Node) HandleResponse(_ context.Context, req *clusterpb.ResponseMessage) (*clusterpb.MemberHandleResponse, error) {
+	if sc := tracing.Extract(req.Metadata); sc.IsValid() {
+		span := n.handler.tracer.Start("response", sc)
+		span.SetAttribute("nano.session_id", strconv.FormatInt(req.SessionId, 10))
+		defer span.End()
+	}
 	s := n.findSession(req.SessionId)
 	if s == nil {
 		return &clusterpb.MemberHandleResponse{}, fmt.Errorf("session not found: %v", req.SessionId)
 	}
+	if req.Error {
+		e, err := message.DecodeError(req.Data)
+		if err != nil {
+			return nil, err
+		}
+		return &clusterpb.MemberHandleResponse{}, s.ResponseMID(req.Id, e)
+	}
 	return &clusterpb.MemberHandleResponse{}, s.ResponseMID(req.Id, req.Data)
 }
 
 func (n *Node) NewMember(_ context.Context, req *clusterpb.NewMemberRequest) (*clusterpb.NewMemberResponse, error) {
-	n.handler.addRemoteService(req.MemberInfo)
-	n.cluster.addMember(req.MemberInfo)
+	n.notifyMemberEvent(MemberEvent{Type: MemberAdded, Member: req.MemberInfo})
 	return &clusterpb.NewMemberResponse{}, nil
 }
 
 func (n *Node) DelMember(_ context.Context, req *clusterpb.DelMemberRequest) (*clusterpb.DelMemberResponse, error) {
 	log.Println("DelMember member", req.String())
-	n.handler.delMember(req.ServiceAddr)
-	n.cluster.delMember(req.ServiceAddr)
+	n.notifyMemberEvent(MemberEvent{Type: MemberRemoved, Member: &clusterpb.MemberInfo{ServiceAddr: req.ServiceAddr}})
 	return &clusterpb.DelMemberResponse{}, nil
 }
 
+// notifyMemberEvent dispatches the membership change pushed by the master
+func (n *Node) notifyMemberEvent(event MemberEvent) {
+	if d, ok := n.discovery.(*masterDiscovery); ok {
+		d.notify(event)
+		return
+	}
+	n.applyMemberEvent(event)
+}
+
+// applyMemberEvent updates the remote services with the membership change
+func (n *Node) applyMemberEvent(event MemberEvent) {
+	if event.Member == nil || event.Member.ServiceAddr == n.ServiceAddr {
+		return
+	}
+	switch event.Type {
+	case MemberAdded:
+		// Drop the stale services if the member registers again
+		n.handler.delMember(event.Member.ServiceAddr)
+		n.handler.addRemoteService(event.Member)
+		n.cluster.addMember(event.Member)
+	case MemberRemoved:
+		n.handler.delMember(event.Member.ServiceAddr)
+		n.unbindSessions(event.Member.ServiceAddr)
+		n.backendStreams.closeStream(event.Member.ServiceAddr)
+		n.closeBatcher(event.Member.ServiceAddr)
+		n.cluster.delMember(event.Member.ServiceAddr)
+	}
+}
+
 // SessionClosed implements the MemberServer interface
 func (n *Node) SessionClosed(_ context.Context, req *clusterpb.SessionClosedRequest) (*clusterpb.SessionClosedResponse, error) {
 	n.mu.Lock()
 	s, found := n.sessions[req.SessionId]
 	delete(n.sessions, req.SessionId)
+	delete(n.moving, req.SessionId)
 	n.mu.Unlock()
 	if found {
-		scheduler.PushTask(func() { session.Lifetime.Close(s) })
+		if ac, ok := s.NetworkEntity().(*acceptor); ok {
+			ac.cancel()
+		}
+		n.closeSession(s)
 	}
 	return &clusterpb.SessionClosedResponse{}, nil
 }
@@ -421,33 +994,58 @@ func (n *Node) CloseSession(_ context.Context, req *clusterpb.CloseSessionReques
 	return &clusterpb.CloseSessionResponse{}, nil
 }
 
+// KickSession implements the MemberServer interface
+func (n *Node) KickSession(_ context.Context, req *clusterpb.KickSessionRequest) (*clusterpb.KickSessionResponse, error) {
+	n.mu.Lock()
+	s, found := n.sessions[req.SessionId]
+	delete(n.sessions, req.SessionId)
+	n.mu.Unlock()
+	if found {
+		if err := s.Kick(session.KickReason{Code: int(req.Code), Message: req.Message}); err != nil {
+			return nil, err
+		}
+	}
+	return &clusterpb.KickSessionResponse{}, nil
+}
+
+// BindSession implements the MemberServer interface
+func (n *Node) BindSession(_ context.Context, req *clusterpb.BindSessionRequest) (*clusterpb.BindSessionResponse, error) {
+	if s := n.findSession(req.SessionId); s != nil {
+		if err := s.Bind(req.Uid); err != nil {
+			return nil, err
+		}
+	}
+	return &clusterpb.BindSessionResponse{}, nil
+}
+
 // ticker send heartbeat register info to master
 func (n *Node) keepalive() {
 	if n.keepaliveExit == nil {
 		n.keepaliveExit = make(chan struct{})
 	}
-	if n.AdvertiseAddr == "" || n.IsMaster {
+	if len(n.masterAddrs()) == 0 || n.IsMaster {
 		return
 	}
 	heartbeat := func() {
-		pool, err := n.rpcClient.getConnPool(n.AdvertiseAddr)
+		err := n.callMaster(func(client clusterpb.MasterClient) error {
+			resp, err := client.Heartbeat(context.Background(), &clusterpb.HeartbeatRequest{
+				MemberInfo: n.memberInfo(),
+				Load:       n.Load(),
+			})
+			if err != nil {
+				return err
+			}
+			n.setLoads(resp.Loads)
+			return nil
+		})
 		if err != nil {
-			log.Println("rpcClient master conn", err)
-			return
-		}
-		masterCli := clusterpb.NewMasterClient(pool.Get())
-		if _, err := masterCli.Heartbeat(context.Background(), &clusterpb.HeartbeatRequest{
-			MemberInfo: &clusterpb.MemberInfo{
-				Label:       n.Label,
-				ServiceAddr: n.ServiceAddr,
-				Services:    n.handler.LocalService(),
-			},
-		}); err != nil {
 			log.Println("Member send heartbeat error", err)
 		}
 	}
 	go func() {
-		ticker := time.NewTicker(env.Heartbeat)
+		// Report the load as soon as joined the cluster
+		heartbeat()
+		ticker := time.NewTicker(n.Heartbeat)
 		for {
 			select {
 			case <-ticker.C:
@@ -460,3 +1058,58 @@ func (n *Node) keepalive() {
 		}
 	}()
 }
+
+func (n *Node) memberInfo() *clusterpb.MemberInfo {
+	return &clusterpb.MemberInfo{
+		Label:       n.Label,
+		ServiceAddr: n.ServiceAddr,
+		Services:    n.handler.LocalService(),
+		Weight:      int32(n.Weight),
+		Draining:    n.IsDraining(),
+	}
+}
+
+// masterAddrs returns the addresses of all master nodes
+func (n *Node) masterAddrs() []string {
+	if len(n.AdvertiseAddrs) > 0 {
+		return n.AdvertiseAddrs
+	}
+	if n.AdvertiseAddr != "" {
+		return []string{n.AdvertiseAddr}
+	}
+	return nil
+}
+
+// callMaster invokes fn with the client of current master, and fails over to
+// the next master address if the current one is unreachable or not the leader
+func (n *Node) callMaster(fn func(client clusterpb.MasterClient) error) error {
+	addrs := n.masterAddrs()
+	var err error
+	for range addrs {
+		index := atomic.LoadUint32(&n.masterIndex)
+		pool, e := n.rpcClient.getConnPool(addrs[int(index)%len(addrs)])
+		if e == nil {
+			if e = fn(clusterpb.NewMasterClient(pool.Get())); e == nil {
+				return nil
+			}
+		}
+		err = e
+		atomic.CompareAndSwapUint32(&n.masterIndex, index, index+1)
+	}
+	return err
+}
+
+// Leader returns the address of the leader master known by current node
+func (n *Node) Leader() string {
+	if n.IsMaster {
+		if n.cluster == nil {
+			return ""
+		}
+		return n.cluster.leader()
+	}
+	addrs := n.masterAddrs()
+	if len(addrs) == 0 {
+		return ""
+	}
+	return addrs[int(atomic.LoadUint32(&n.masterIndex))%len(addrs)]
+}
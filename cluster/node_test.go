@@ -1,14 +1,23 @@
 package cluster_test
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/lonng/nano/benchmark/io"
 	"github.com/lonng/nano/benchmark/testdata"
+	"github.com/lonng/nano/client"
 	"github.com/lonng/nano/cluster"
 	"github.com/lonng/nano/component"
 	"github.com/lonng/nano/scheduler"
+	"github.com/lonng/nano/serialize"
+	jsonserializer "github.com/lonng/nano/serialize/json"
+	"github.com/lonng/nano/serialize/protobuf"
 	"github.com/lonng/nano/session"
 	. "github.com/pingcap/check"
 )
@@ -43,40 +52,89 @@ func (c *GameComponent) Test2(session *session.Session, ping *testdata.Ping) err
 	return session.Response(&testdata.Pong{Content: "game server pong2"})
 }
 
+func (c *GameComponent) Test3(session *session.Session, ping *testdata.Ping) error {
+	return errors.New("game server error")
+}
+
+func (c *GameComponent) Test4(session *session.Session, ping *testdata.Ping) error {
+	return session.Response(&testdata.Pong{Content: ping.Content + session.String("game")})
+}
+
+func (c *GameComponent) Test5(session *session.Session, ping *testdata.Ping) error {
+	session.Set("game", ping.Content)
+	return session.Response(&testdata.Pong{})
+}
+
+func (c *GameComponent) Test6(session *session.Session, ping *testdata.Ping) error {
+	return nil
+}
+
 func TestNode(t *testing.T) {
 	TestingT(t)
 }
 
-func (s *nodeSuite) TestNodeStartup(c *C) {
+func (s *nodeSuite) SetUpSuite(c *C) {
 	go scheduler.Sched()
-	defer scheduler.Close()
+}
+
+func (s *nodeSuite) TearDownSuite(c *C) {
+	scheduler.Close()
+}
 
+// startNode starts a node listening on the ports chosen by the system, the
+// addresses are rewritten to the bound ones once the node started up
+func startNode(c *C, opts cluster.Options) *cluster.Node {
+	if opts.Components == nil {
+		opts.Components = &component.Components{}
+	}
+	node := &cluster.Node{Options: opts, ServiceAddr: "127.0.0.1:0"}
+	c.Assert(node.Startup(), IsNil)
+	return node
+}
+
+// freeAddrs reserves n addresses for the nodes which must know the addresses
+// of each other before started up
+func freeAddrs(c *C, n int) []string {
+	var addrs []string
+	for i := 0; i < n; i++ {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		c.Assert(err, IsNil)
+		defer listener.Close()
+		addrs = append(addrs, listener.Addr().String())
+	}
+	return addrs
+}
+
+// waitFor polls cond until it is satisfied or the timeout elapsed
+func waitFor(c *C, timeout time.Duration, cond func() bool) {
+	deadline := time.Now().Add(timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			c.Fatal("condition not satisfied in ", timeout)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func (s *nodeSuite) TestNodeStartup(c *C) {
 	masterComps := &component.Components{}
 	masterComps.Register(&MasterComponent{})
-	masterNode := &cluster.Node{
-		Options: cluster.Options{
-			IsMaster:   true,
-			Components: masterComps,
-		},
-		ServiceAddr: "127.0.0.1:4450",
-	}
-	err := masterNode.Startup()
-	c.Assert(err, IsNil)
+	masterNode := startNode(c, cluster.Options{
+		IsMaster:   true,
+		Components: masterComps,
+	})
+	defer masterNode.Shutdown()
 	masterHandler := masterNode.Handler()
 	c.Assert(masterHandler.LocalService(), DeepEquals, []string{"MasterComponent"})
 
 	member1Comps := &component.Components{}
 	member1Comps.Register(&GateComponent{})
-	memberNode1 := &cluster.Node{
-		Options: cluster.Options{
-			AdvertiseAddr: "127.0.0.1:4450",
-			ClientAddr:    "127.0.0.1:14452",
-			Components:    member1Comps,
-		},
-		ServiceAddr: "127.0.0.1:14451",
-	}
-	err = memberNode1.Startup()
-	c.Assert(err, IsNil)
+	memberNode1 := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		ClientAddr:    "127.0.0.1:0",
+		Components:    member1Comps,
+	})
+	defer memberNode1.Shutdown()
 	member1Handler := memberNode1.Handler()
 	c.Assert(masterHandler.LocalService(), DeepEquals, []string{"MasterComponent"})
 	c.Assert(masterHandler.RemoteService(), DeepEquals, []string{"GateComponent"})
@@ -85,15 +143,11 @@ func (s *nodeSuite) TestNodeStartup(c *C) {
 
 	member2Comps := &component.Components{}
 	member2Comps.Register(&GameComponent{})
-	memberNode2 := &cluster.Node{
-		Options: cluster.Options{
-			AdvertiseAddr: "127.0.0.1:4450",
-			Components:    member2Comps,
-		},
-		ServiceAddr: "127.0.0.1:24451",
-	}
-	err = memberNode2.Startup()
-	c.Assert(err, IsNil)
+	memberNode2 := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		Components:    member2Comps,
+	})
+	defer memberNode2.Shutdown()
 	member2Handler := memberNode2.Handler()
 	c.Assert(masterHandler.LocalService(), DeepEquals, []string{"MasterComponent"})
 	c.Assert(masterHandler.RemoteService(), DeepEquals, []string{"GameComponent", "GateComponent"})
@@ -110,7 +164,7 @@ func (s *nodeSuite) TestNodeStartup(c *C) {
 	})
 
 	// Connect to gate server
-	if err := connector.Start("127.0.0.1:14452"); err != nil {
+	if err := connector.Start(memberNode1.ClientAddr); err != nil {
 		c.Assert(err, IsNil)
 	}
 	<-chWait
@@ -118,7 +172,7 @@ func (s *nodeSuite) TestNodeStartup(c *C) {
 	connector.On("test", func(data interface{}) {
 		onResult <- string(data.([]byte))
 	})
-	err = connector.Notify("GateComponent.Test", &testdata.Ping{Content: "ping"})
+	err := connector.Notify("GateComponent.Test", &testdata.Ping{Content: "ping"})
 	c.Assert(err, IsNil)
 	c.Assert(strings.Contains(<-onResult, "gate server pong"), IsTrue)
 
@@ -142,3 +196,71 @@ func (s *nodeSuite) TestNodeStartup(c *C) {
 	c.Assert(err, IsNil)
 	c.Assert(strings.Contains(<-onResult, "master server pong"), IsTrue)
 }
+
+type ScopedComponent struct{ component.Base }
+
+func (c *ScopedComponent) Ping(s *session.Session, ping *testdata.Ping) error {
+	return s.Response(&testdata.Pong{Content: ping.Content})
+}
+
+func (s *nodeSuite) TestScopedOptions(c *C) {
+	type scoped struct {
+		node   *cluster.Node
+		sched  *scheduler.Scheduler
+		closed chan int64
+	}
+	var nodes []scoped
+	for i, ser := range []serialize.Serializer{jsonserializer.NewSerializer(), protobuf.NewSerializer()} {
+		sched := scheduler.NewScheduler(fmt.Sprintf("scoped-%d", i))
+		sched.Start()
+		defer sched.Close()
+
+		closed := make(chan int64, 1)
+		lifetime := session.NewLifetime()
+		lifetime.OnClosed(func(s *session.Session) { closed <- s.ID() })
+
+		comps := &component.Components{}
+		comps.Register(&ScopedComponent{})
+		node := startNode(c, cluster.Options{
+			ClientAddr: "127.0.0.1:0",
+			Components: comps,
+			Serializer: ser,
+			Scheduler:  sched,
+			Lifetime:   lifetime,
+			NodeID:     uint64(i + 1),
+		})
+		defer node.Shutdown()
+		nodes = append(nodes, scoped{node: node, sched: sched, closed: closed})
+	}
+
+	// Block the scheduler of the second node until the test finished
+	blocked, release := make(chan struct{}), make(chan struct{})
+	nodes[1].sched.PushTask(func() {
+		close(blocked)
+		<-release
+	})
+	<-blocked
+	defer close(release)
+
+	// The first node serializes the payloads in JSON on its own scheduler
+	cli, err := client.Dial(context.Background(), nodes[0].node.ClientAddr, client.WithSerializer(jsonserializer.NewSerializer()))
+	c.Assert(err, IsNil)
+	pong := &testdata.Pong{}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	c.Assert(cli.Request(ctx, "ScopedComponent.Ping", &testdata.Ping{Content: "json"}, pong), IsNil)
+	c.Assert(pong.Content, Equals, "json")
+	cli.Close()
+
+	// Only the lifetime callbacks of the first node are called
+	select {
+	case <-nodes[0].closed:
+	case <-time.After(time.Second):
+		c.Fatal("lifetime callback not called")
+	}
+	select {
+	case <-nodes[1].closed:
+		c.Fatal("lifetime callback of the other node called")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,281 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cluster
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"time"
+
+	"github.com/lonng/nano/internal/log"
+)
+
+// agentResumeBacklog is the number of recent data packets kept by an agent,
+// which will be replayed to the client after the session resumed
+const agentResumeBacklog = 64
+
+// outgoing represents a data packet sent to the client
+type outgoing struct {
+	seq  uint64 // sequence of the data packet in the session
+	data []byte // encoded packet
+}
+
+// resumeRequest is the sys part of the handshake data to resume a session,
+// acked is the number of data packets the client has received in the session
+type resumeRequest struct {
+	Sys struct {
+		Resume string  `json:"resume"`
+		Acked  *uint64 `json:"acked"`
+	} `json:"sys"`
+}
+
+func newResumeToken() string {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(buf)
+}
+
+// record keeps the data packet which is going to be written to the client
+func (a *agent) record(p []byte) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.seq++
+	a.written = a.seq
+	if len(a.outbox) >= agentResumeBacklog {
+		copy(a.outbox, a.outbox[1:])
+		a.outbox = a.outbox[:len(a.outbox)-1]
+	}
+	a.outbox = append(a.outbox, outgoing{seq: a.seq, data: p})
+}
+
+// buffer keeps the message sent to a parked session, which will be replayed
+// after the session resumed
+func (a *agent) buffer(m pendingMessage) error {
+	a.mu.Lock()
+	if successor := a.successor; successor != nil {
+		a.mu.Unlock()
+		return successor.send(m)
+	}
+	defer a.mu.Unlock()
+
+	if a.seq-a.written >= agentResumeBacklog {
+		a.node.metrics.bufferExceeded.Inc()
+		return ErrBufferExceed
+	}
+	p, err := a.encode(m)
+	if err != nil {
+		return err
+	}
+	a.seq++
+	a.outbox = append(a.outbox, outgoing{seq: a.seq, data: p})
+	return nil
+}
+
+// resumedBy returns the agent which resumed the session
+func (a *agent) resumedBy() *agent {
+	if !a.resumable {
+		return nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.successor
+}
+
+// drain buffers the messages which have not been written before the
+// connection broken
+func (a *agent) drain() {
+	for {
+		select {
+		case m := <-a.chSend:
+			if m.packet != nil {
+				a.mu.Lock()
+				if a.written >= m.seq {
+					a.written = m.seq - 1
+				}
+				a.mu.Unlock()
+				continue
+			}
+			if err := a.buffer(m); err != nil {
+				log.Println(fmt.Sprintf("Buffer message error, SessionID=%d, Error=%s", a.session.ID(), err.Error()))
+			}
+		default:
+			return
+		}
+	}
+}
+
+// park keeps the session of a broken connection, the client can resume it on
+// a new connection with the resume token before the window elapsed
+func (h *LocalHandler) park(a *agent) bool {
+	window := h.currentNode.ResumeWindow
+	if window <= 0 {
+		return false
+	}
+
+	h.resumeMu.Lock()
+	defer h.resumeMu.Unlock()
+	// The parked sessions have been closed by current node shutting down
+	if h.parked == nil || !atomic.CompareAndSwapInt32(&a.state, statusWorking, statusParked) {
+		return false
+	}
+
+	if h.currentNode.Debug {
+		log.Println(fmt.Sprintf("Session parked, ID=%d, UID=%d", a.session.ID(), a.session.UID()))
+	}
+
+	token := a.token
+	h.parked[token] = a
+	a.expiry = time.AfterFunc(window, func() {
+		if parked := h.unpark(token); parked != nil {
+			h.closeAgent(parked)
+		}
+	})
+	return true
+}
+
+func (h *LocalHandler) unpark(token string) *agent {
+	h.resumeMu.Lock()
+	defer h.resumeMu.Unlock()
+
+	a, found := h.parked[token]
+	if found {
+		delete(h.parked, token)
+		a.expiry.Stop()
+	}
+	return a
+}
+
+// closeParked closes the parked sessions once current node shutting down, and
+// no session will be parked later
+func (h *LocalHandler) closeParked() {
+	h.resumeMu.Lock()
+	parked := h.parked
+	h.parked = nil
+	h.resumeMu.Unlock()
+
+	for _, a := range parked {
+		a.expiry.Stop()
+		h.closeAgent(a)
+	}
+}
+
+// resume issues a new resume token to the agent, and reattaches the parked
+// session to the agent if the handshake data carries a valid resume token.
+// The parked agent returned must be released by resumed after the handshake
+// response written
+func (h *LocalHandler) resume(a *agent, data []byte) *agent {
+	if !a.resumable {
+		return nil
+	}
+	a.token = newResumeToken()
+
+	req := &resumeRequest{}
+	if err := json.Unmarshal(data, req); err != nil || req.Sys.Resume == "" {
+		return nil
+	}
+	parked := h.unpark(req.Sys.Resume)
+	if parked == nil {
+		return nil
+	}
+	// The parked session has been closed by the application
+	if parked.status() != statusParked {
+		h.closeAgent(parked)
+		return nil
+	}
+
+	parked.mu.Lock()
+	acked := parked.written
+	if req.Sys.Acked != nil && *req.Sys.Acked < parked.seq {
+		acked = *req.Sys.Acked
+	}
+	// The packets the client has not received have been discarded
+	discarded := len(parked.outbox) > 0 && parked.outbox[0].seq > acked+1
+	lastMid := parked.lastMid
+	parked.mu.Unlock()
+	if discarded {
+		log.Println(fmt.Sprintf("Session cannot be resumed, ID=%d, Acked=%d", parked.session.ID(), acked))
+		h.closeAgent(parked)
+		return nil
+	}
+
+	h.currentNode.removeSession(a.session.ID())
+	a.session = parked.session
+	a.srv = reflect.ValueOf(a.session)
+	a.lastMid = lastMid
+	a.mu.Lock()
+	a.seq = acked
+	a.written = acked
+	a.mu.Unlock()
+
+	if h.currentNode.Debug {
+		log.Println(fmt.Sprintf("Session resumed, ID=%d, UID=%d, Remote=%s", a.session.ID(), a.session.UID(), a.conn.RemoteAddr()))
+	}
+	return parked
+}
+
+// resumed replays the packets the client has not received and releases the
+// parked agent, the messages sent to the parked agent will be forwarded to the
+// new one from now on. The packets are replayed without holding the lock of the
+// parked agent, which keeps buffering the messages until all replayed.
+func (a *agent) resumed(parked *agent) {
+	a.mu.Lock()
+	acked := a.written
+	a.mu.Unlock()
+
+	for next := 0; ; {
+		parked.mu.Lock()
+		pending := append([]outgoing{}, parked.outbox[next:]...)
+		next = len(parked.outbox)
+		if len(pending) == 0 {
+			parked.successor = a
+			parked.mu.Unlock()
+			break
+		}
+		parked.mu.Unlock()
+
+		a.mu.Lock()
+		a.outbox = append(a.outbox, pending...)
+		if last := pending[len(pending)-1].seq; last > a.seq {
+			a.seq = last
+		}
+		a.mu.Unlock()
+		for _, o := range pending {
+			if o.seq > acked {
+				a.send(pendingMessage{seq: o.seq, packet: o.data})
+			}
+		}
+	}
+
+	parked.setStatus(statusClosed)
+	select {
+	case <-parked.chDie:
+	default:
+		close(parked.chDie)
+	}
+	a.session.SetNetworkEntity(a)
+}
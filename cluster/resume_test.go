@@ -0,0 +1,181 @@
+package cluster_test
+
+import (
+	"encoding/json"
+	"net"
+	"time"
+
+	"github.com/lonng/nano/benchmark/testdata"
+	"github.com/lonng/nano/cluster"
+	"github.com/lonng/nano/component"
+	"github.com/lonng/nano/internal/codec"
+	"github.com/lonng/nano/internal/message"
+	"github.com/lonng/nano/internal/packet"
+	"github.com/lonng/nano/serialize/protobuf"
+	"github.com/lonng/nano/session"
+	. "github.com/pingcap/check"
+	"google.golang.org/protobuf/proto"
+)
+
+type ResumeComponent struct {
+	component.Base
+	session *session.Session
+}
+
+func (c *ResumeComponent) Set(s *session.Session, ping *testdata.Ping) error {
+	c.session = s
+	s.Set("content", ping.Content)
+	return s.Response(&testdata.Pong{Content: "ok"})
+}
+
+func (c *ResumeComponent) Get(s *session.Session, ping *testdata.Ping) error {
+	c.session = s
+	return s.Response(&testdata.Pong{Content: "content:" + s.String("content")})
+}
+
+// rawClient speaks the nano protocol over a TCP connection
+type rawClient struct {
+	conn    net.Conn
+	decoder *codec.Decoder
+	packets []*packet.Packet
+}
+
+func dialRaw(c *C, addr string, handshake string) (*rawClient, string) {
+	conn, err := net.Dial("tcp", addr)
+	c.Assert(err, IsNil)
+	r := &rawClient{conn: conn, decoder: codec.NewDecoder()}
+	r.write(c, packet.Handshake, []byte(handshake))
+
+	p := r.read(c)
+	c.Assert(p.Type, Equals, packet.Type(packet.Handshake))
+	resp := struct {
+		Sys struct {
+			Resume string `json:"resume"`
+		} `json:"sys"`
+	}{}
+	c.Assert(json.Unmarshal(p.Data, &resp), IsNil)
+	r.write(c, packet.HandshakeAck, nil)
+	return r, resp.Sys.Resume
+}
+
+func (r *rawClient) write(c *C, typ packet.Type, data []byte) {
+	p, err := codec.Encode(typ, data)
+	c.Assert(err, IsNil)
+	_, err = r.conn.Write(p)
+	c.Assert(err, IsNil)
+}
+
+func (r *rawClient) read(c *C) *packet.Packet {
+	buf := make([]byte, 2048)
+	for len(r.packets) == 0 {
+		r.conn.SetReadDeadline(time.Now().Add(time.Second))
+		n, err := r.conn.Read(buf)
+		c.Assert(err, IsNil)
+		packets, err := r.decoder.Decode(buf[:n])
+		c.Assert(err, IsNil)
+		r.packets = append(r.packets, packets...)
+	}
+	p := r.packets[0]
+	r.packets = r.packets[1:]
+	return p
+}
+
+func (r *rawClient) readMessage(c *C, v proto.Message) *message.Message {
+	p := r.read(c)
+	c.Assert(p.Type, Equals, packet.Type(packet.Data))
+	m, err := message.Decode(p.Data)
+	c.Assert(err, IsNil)
+	c.Assert(protobuf.NewSerializer().Unmarshal(m.Data, v), IsNil)
+	return m
+}
+
+func (r *rawClient) request(c *C, id uint64, route string, v proto.Message) *testdata.Pong {
+	data, err := protobuf.NewSerializer().Marshal(v)
+	c.Assert(err, IsNil)
+	m, err := message.Encode(&message.Message{Type: message.Request, ID: id, Route: route, Data: data})
+	c.Assert(err, IsNil)
+	r.write(c, packet.Data, m)
+
+	pong := &testdata.Pong{}
+	resp := r.readMessage(c, pong)
+	c.Assert(resp.Type, Equals, message.Type(message.Response))
+	c.Assert(resp.ID, Equals, id)
+	return pong
+}
+
+func (s *nodeSuite) TestSessionResume(c *C) {
+	comp := &ResumeComponent{}
+	comps := &component.Components{}
+	comps.Register(comp)
+	node := startNode(c, cluster.Options{
+		ClientAddr:   "127.0.0.1:0",
+		ResumeWindow: 300 * time.Millisecond,
+		Components:   comps,
+	})
+	defer node.Shutdown()
+
+	client, token := dialRaw(c, node.ClientAddr, `{"sys":{}}`)
+	c.Assert(token, Not(Equals), "")
+	client.request(c, 1, "ResumeComponent.Set", &testdata.Ping{Content: "resume"})
+	sid := comp.session.ID()
+
+	// Messages sent to the parked session are replayed after resumed
+	client.conn.Close()
+	waitFor(c, time.Second, func() bool { return node.ParkedSessions() == 1 })
+	c.Assert(comp.session.Push("test", &testdata.Pong{Content: "missed"}), IsNil)
+
+	client, newToken := dialRaw(c, node.ClientAddr, `{"sys":{"resume":"`+token+`"}}`)
+	c.Assert(newToken, Not(Equals), token)
+	pong := &testdata.Pong{}
+	m := client.readMessage(c, pong)
+	c.Assert(m.Type, Equals, message.Type(message.Push))
+	c.Assert(pong.Content, Equals, "missed")
+	c.Assert(client.request(c, 2, "ResumeComponent.Get", &testdata.Ping{}).Content, Equals, "content:resume")
+	c.Assert(comp.session.ID(), Equals, sid)
+	resumed := comp.session
+	client.conn.Close()
+
+	// The resume token can be used only once
+	client, _ = dialRaw(c, node.ClientAddr, `{"sys":{"resume":"`+token+`"}}`)
+	c.Assert(client.request(c, 1, "ResumeComponent.Get", &testdata.Ping{}).Content, Equals, "content:")
+	c.Assert(comp.session.ID(), Not(Equals), sid)
+	client.conn.Close()
+
+	// The session is closed after the resume window elapsed
+	waitFor(c, time.Second, func() bool { return node.ParkedSessions() == 1 })
+	waitFor(c, time.Second, func() bool { return node.ParkedSessions() == 0 })
+	client, _ = dialRaw(c, node.ClientAddr, `{"sys":{"resume":"`+newToken+`"}}`)
+	c.Assert(client.request(c, 1, "ResumeComponent.Get", &testdata.Ping{}).Content, Equals, "content:")
+	client.conn.Close()
+	c.Assert(resumed.Push("test", &testdata.Pong{}), Equals, cluster.ErrBrokenPipe)
+}
+
+func (s *nodeSuite) TestParkedSessionShutdown(c *C) {
+	comp := &ResumeComponent{}
+	comps := &component.Components{}
+	comps.Register(comp)
+	closed := make(chan int64, 1)
+	lifetime := session.NewLifetime()
+	lifetime.OnClosed(func(s *session.Session) { closed <- s.ID() })
+	node := startNode(c, cluster.Options{
+		ClientAddr:   "127.0.0.1:0",
+		ResumeWindow: time.Hour,
+		Components:   comps,
+		Lifetime:     lifetime,
+	})
+
+	client, _ := dialRaw(c, node.ClientAddr, `{"sys":{}}`)
+	client.request(c, 1, "ResumeComponent.Set", &testdata.Ping{Content: "resume"})
+	sid := comp.session.ID()
+	client.conn.Close()
+
+	// The parked session is closed once current node shut down rather than
+	// the resume window elapsed
+	node.Shutdown()
+	select {
+	case id := <-closed:
+		c.Assert(id, Equals, sid)
+	case <-time.After(time.Second):
+		c.Fatal("parked session is not closed")
+	}
+}
@@ -0,0 +1,202 @@
+package cluster_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/lonng/nano/benchmark/testdata"
+	"github.com/lonng/nano/client"
+	"github.com/lonng/nano/cluster"
+	"github.com/lonng/nano/component"
+	"github.com/lonng/nano/internal/message"
+	"github.com/lonng/nano/internal/packet"
+	"github.com/lonng/nano/serialize/protobuf"
+	"github.com/lonng/nano/session"
+	. "github.com/pingcap/check"
+)
+
+func (c *GameComponent) Kick(s *session.Session, ping *testdata.Ping) error {
+	if err := s.Push("test", &testdata.Pong{Content: "bye"}); err != nil {
+		return err
+	}
+	return s.Kick(session.KickReason{Code: 1001, Message: ping.Content})
+}
+
+func (s *nodeSuite) TestSessionKick(c *C) {
+	masterNode := startNode(c, cluster.Options{IsMaster: true})
+	defer masterNode.Shutdown()
+
+	gateNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		ClientAddr:    "127.0.0.1:0",
+	})
+	defer gateNode.Shutdown()
+
+	gameComps := &component.Components{}
+	gameComps.Register(&GameComponent{})
+	gameNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		Components:    gameComps,
+	})
+	defer gameNode.Shutdown()
+
+	client, _ := dialRaw(c, gateNode.ClientAddr, `{"sys":{}}`)
+	defer client.conn.Close()
+	data, err := protobuf.NewSerializer().Marshal(&testdata.Ping{Content: "logged in elsewhere"})
+	c.Assert(err, IsNil)
+	m, err := message.Encode(&message.Message{Type: message.Notify, Route: "GameComponent.Kick", Data: data})
+	c.Assert(err, IsNil)
+	client.write(c, packet.Data, m)
+
+	// Pending messages are flushed before the kick packet
+	pong := &testdata.Pong{}
+	client.readMessage(c, pong)
+	c.Assert(pong.Content, Equals, "bye")
+	p := client.read(c)
+	c.Assert(p.Type, Equals, packet.Type(packet.Kick))
+	reason := session.KickReason{}
+	c.Assert(json.Unmarshal(p.Data, &reason), IsNil)
+	c.Assert(reason, DeepEquals, session.KickReason{Code: 1001, Message: "logged in elsewhere"})
+
+	_, err = client.conn.Read(make([]byte, 1))
+	c.Assert(err, NotNil)
+}
+
+var roomKey = session.NewReplicatedKey[string]("cluster_test.room", nil)
+
+func (c *GateComponent) Enter(s *session.Session, ping *testdata.Ping) error {
+	if err := roomKey.Set(s, ping.Content); err != nil {
+		return err
+	}
+	return s.Response(&testdata.Pong{Content: "entered"})
+}
+
+func (c *GameComponent) Room(s *session.Session, _ *testdata.Ping) error {
+	room, err := roomKey.Get(s)
+	if err != nil {
+		return s.Response(&testdata.Pong{Content: err.Error()})
+	}
+	return s.Response(&testdata.Pong{Content: room})
+}
+
+func (s *nodeSuite) TestReplicatedKey(c *C) {
+	masterNode := startNode(c, cluster.Options{IsMaster: true})
+	defer masterNode.Shutdown()
+
+	gateComps := &component.Components{}
+	gateComps.Register(&GateComponent{})
+	gateNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		ClientAddr:    "127.0.0.1:0",
+		Components:    gateComps,
+	})
+	defer gateNode.Shutdown()
+
+	gameComps := &component.Components{}
+	gameComps.Register(&GameComponent{})
+	gameNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		Components:    gameComps,
+	})
+	defer gameNode.Shutdown()
+
+	cli, err := client.Dial(context.Background(), gateNode.ClientAddr)
+	c.Assert(err, IsNil)
+	defer cli.Close()
+	request := func(route, content string) string {
+		pong := &testdata.Pong{}
+		c.Assert(cli.Request(context.Background(), route, &testdata.Ping{Content: content}, pong), IsNil)
+		return pong.Content
+	}
+
+	c.Assert(request("GameComponent.Room", ""), Equals, session.ErrKeyNotFound.Error())
+	c.Assert(request("GateComponent.Enter", "lobby"), Equals, "entered")
+	c.Assert(request("GameComponent.Room", ""), Equals, "lobby")
+	c.Assert(request("GateComponent.Enter", "arena"), Equals, "entered")
+	c.Assert(request("GameComponent.Room", ""), Equals, "arena")
+}
+
+type RoomComponent struct{ component.Base }
+
+func whoami(s *session.Session) *testdata.Pong {
+	return &testdata.Pong{Content: fmt.Sprintf("%d %s", s.UID(), s.String("nickname"))}
+}
+
+func (c *GateComponent) Login(s *session.Session, _ *testdata.Ping) error {
+	if err := s.Bind(7); err != nil {
+		return err
+	}
+	s.Set("nickname", "bob")
+	return s.Response(whoami(s))
+}
+
+func (c *GateComponent) Whoami(s *session.Session, _ *testdata.Ping) error {
+	return s.Response(whoami(s))
+}
+
+func (c *GameComponent) Whoami(s *session.Session, _ *testdata.Ping) error {
+	return s.Response(whoami(s))
+}
+
+func (c *GameComponent) Rebind(s *session.Session, _ *testdata.Ping) error {
+	if err := s.Bind(9); err != nil {
+		return err
+	}
+	return s.Response(whoami(s))
+}
+
+func (c *RoomComponent) Whoami(s *session.Session, _ *testdata.Ping) error {
+	return s.Response(whoami(s))
+}
+
+func (s *nodeSuite) TestSessionBind(c *C) {
+	masterNode := startNode(c, cluster.Options{IsMaster: true})
+	defer masterNode.Shutdown()
+
+	gateComps := &component.Components{}
+	gateComps.Register(&GateComponent{})
+	gateNode := startNode(c, cluster.Options{
+		AdvertiseAddr:   masterNode.ServiceAddr,
+		ClientAddr:      "127.0.0.1:0",
+		Components:      gateComps,
+		ForwardedValues: []string{"nickname"},
+	})
+	defer gateNode.Shutdown()
+
+	gameComps := &component.Components{}
+	gameComps.Register(&GameComponent{})
+	gameNode := startNode(c, cluster.Options{
+		AdvertiseAddr:   masterNode.ServiceAddr,
+		Components:      gameComps,
+		ForwardedValues: []string{"nickname"},
+	})
+	defer gameNode.Shutdown()
+
+	roomComps := &component.Components{}
+	roomComps.Register(&RoomComponent{})
+	roomNode := startNode(c, cluster.Options{
+		AdvertiseAddr:   masterNode.ServiceAddr,
+		Components:      roomComps,
+		ForwardedValues: []string{"nickname"},
+	})
+	defer roomNode.Shutdown()
+
+	cli, err := client.Dial(context.Background(), gateNode.ClientAddr)
+	c.Assert(err, IsNil)
+	defer cli.Close()
+	request := func(route string) string {
+		pong := &testdata.Pong{}
+		c.Assert(cli.Request(context.Background(), route, &testdata.Ping{}, pong), IsNil)
+		return pong.Content
+	}
+
+	c.Assert(request("GameComponent.Whoami"), Equals, "0 ")
+	c.Assert(request("GateComponent.Login"), Equals, "7 bob")
+	c.Assert(request("GameComponent.Whoami"), Equals, "7 bob")
+
+	// The UID bound on a backend flows back to the gate and to the other backends
+	c.Assert(request("GameComponent.Rebind"), Equals, "9 bob")
+	c.Assert(request("GateComponent.Whoami"), Equals, "9 bob")
+	c.Assert(request("RoomComponent.Whoami"), Equals, "9 bob")
+}
@@ -0,0 +1,354 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cluster
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/lonng/nano/cluster/clusterpb"
+	"github.com/lonng/nano/internal/log"
+	"github.com/lonng/nano/internal/message"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// streamGateKey is the gRPC metadata key which carries the service address
+	// of the gate opening the stream
+	streamGateKey = "nano-gate-addr"
+
+	// timeoutKey is the message metadata key which carries the request timeout
+	// of the gate, it replaces the gRPC deadline of the unary calls
+	timeoutKey = "nano-timeout"
+)
+
+// stream is a long-lived bidirectional stream between a gate and a backend
+// member, which multiplexes the messages of all sessions of the gate. The
+// messages are sent one by one and the messages of a session are handled one by
+// one, so the messages of a session are delivered in FIFO order, even if they
+// are sent concurrently. The unary calls used without the stream only keep the
+// order of the calls made one after another, e.g. the gate forwarding the
+// messages read from a connection.
+type stream struct {
+	addr  string // service address of the remote member
+	mu    sync.Mutex
+	send  func(*clusterpb.StreamMessage) error
+	close func()
+
+	taskMu sync.Mutex
+	tasks  map[int64][]func() // pending tasks of the sessions being handled
+}
+
+// Send sends the message, it is safe to be called concurrently
+func (s *stream) Send(m *clusterpb.StreamMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.send(m)
+}
+
+// run runs the task of the session after its pending tasks, the tasks of the
+// different sessions run concurrently, so a session waiting, e.g. moving to
+// another member, blocks none of the others. The task runs on the calling
+// goroutine if async is false and no task of the session is pending.
+func (s *stream) run(sid int64, async bool, task func()) {
+	s.taskMu.Lock()
+	if pending, running := s.tasks[sid]; running {
+		s.tasks[sid] = append(pending, task)
+		s.taskMu.Unlock()
+		return
+	}
+	if !async {
+		s.taskMu.Unlock()
+		task()
+		return
+	}
+	if s.tasks == nil {
+		s.tasks = map[int64][]func(){}
+	}
+	s.tasks[sid] = nil
+	s.taskMu.Unlock()
+
+	go func() {
+		for task != nil {
+			task()
+			s.taskMu.Lock()
+			if pending := s.tasks[sid]; len(pending) > 0 {
+				task, s.tasks[sid] = pending[0], pending[1:]
+			} else {
+				task = nil
+				delete(s.tasks, sid)
+			}
+			s.taskMu.Unlock()
+		}
+	}()
+}
+
+// streams holds the streams indexed by the service address of the remote member
+type streams struct {
+	mu     sync.RWMutex
+	m      map[string]*stream
+	closed bool
+}
+
+func (ss *streams) get(addr string) *stream {
+	ss.mu.RLock()
+	defer ss.mu.RUnlock()
+	return ss.m[addr]
+}
+
+// put stores the stream and closes the stale one of the same member, it
+// returns false if the streams have been closed
+func (ss *streams) put(s *stream) bool {
+	ss.mu.Lock()
+	if ss.closed {
+		ss.mu.Unlock()
+		return false
+	}
+	if ss.m == nil {
+		ss.m = map[string]*stream{}
+	}
+	stale := ss.m[s.addr]
+	ss.m[s.addr] = s
+	ss.mu.Unlock()
+
+	if stale != nil {
+		stale.close()
+	}
+	return true
+}
+
+// remove removes the stream if it has not been replaced
+func (ss *streams) remove(s *stream) {
+	ss.mu.Lock()
+	defer ss.mu.Unlock()
+	if ss.m[s.addr] == s {
+		delete(ss.m, s.addr)
+	}
+}
+
+// closeStream closes the stream of the member
+func (ss *streams) closeStream(addr string) {
+	ss.mu.Lock()
+	s := ss.m[addr]
+	delete(ss.m, addr)
+	ss.mu.Unlock()
+
+	if s != nil {
+		s.close()
+	}
+}
+
+func (ss *streams) closeAll() {
+	ss.mu.Lock()
+	all := ss.m
+	ss.m = nil
+	ss.closed = true
+	ss.mu.Unlock()
+
+	for _, s := range all {
+		s.close()
+	}
+}
+
+// openStream returns the stream to the backend member, and opens one if absent
+func (n *Node) openStream(addr string) (*stream, error) {
+	if s := n.backendStreams.get(addr); s != nil {
+		return s, nil
+	}
+
+	n.streamMu.Lock()
+	defer n.streamMu.Unlock()
+	if s := n.backendStreams.get(addr); s != nil {
+		return s, nil
+	}
+
+	pool, err := n.rpcClient.getConnPool(addr)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithCancel(metadata.AppendToOutgoingContext(context.Background(), streamGateKey, n.ServiceAddr))
+	client, err := clusterpb.NewMemberClient(pool.Get()).Stream(ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	s := &stream{addr: addr, send: client.Send, close: cancel}
+	if !n.backendStreams.put(s) {
+		cancel()
+		return nil, ErrStreamClosed
+	}
+
+	go func() {
+		defer cancel()
+		defer n.backendStreams.remove(s)
+		for {
+			m, err := client.Recv()
+			if err != nil {
+				if err != io.EOF && status.Code(err) != codes.Canceled {
+					log.Println("Stream to member", addr, "broken", err)
+				}
+				return
+			}
+			n.dispatch(context.Background(), s, m)
+		}
+	}()
+	return s, nil
+}
+
+// sendStream sends the message through the stream to the backend member, it
+// returns false if the stream is unavailable, then the message should be sent
+// by the unary call. The broken stream is closed to be opened again later.
+func (n *Node) sendStream(addr string, m *clusterpb.StreamMessage) bool {
+	s, err := n.openStream(addr)
+	if err != nil {
+		log.Println(fmt.Sprintf("Open stream to member %s error: %+v", addr, err))
+		return false
+	}
+	if err := s.Send(m); err != nil {
+		log.Println(fmt.Sprintf("Send stream message to member %s error: %+v", addr, err))
+		n.backendStreams.remove(s)
+		s.close()
+		return false
+	}
+	return true
+}
+
+// Stream implements the MemberServer interface, a gate opens the stream to
+// forward the messages of its sessions, and the backend sends the pushes and
+// responses back through it
+func (n *Node) Stream(srv clusterpb.Member_StreamServer) error {
+	md, _ := metadata.FromIncomingContext(srv.Context())
+	var gateAddr string
+	if values := md.Get(streamGateKey); len(values) > 0 {
+		gateAddr = values[0]
+	}
+	if gateAddr == "" {
+		return status.Error(codes.InvalidArgument, "gate address missing")
+	}
+
+	die := make(chan struct{})
+	var once sync.Once
+	s := &stream{addr: gateAddr, send: srv.Send, close: func() { once.Do(func() { close(die) }) }}
+	if !n.gateStreams.put(s) {
+		return ErrStreamClosed
+	}
+	defer n.gateStreams.remove(s)
+
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			m, err := srv.Recv()
+			if err != nil {
+				errCh <- err
+				return
+			}
+			n.dispatch(srv.Context(), s, m)
+		}
+	}()
+
+	// The stream is closed once the gate closed it or the node shutdown
+	select {
+	case err := <-errCh:
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	case <-die:
+		return nil
+	}
+}
+
+// dispatch handles the message received from the stream, the requests and the
+// notifications, which wait for the sessions moving to other members, are
+// handled asynchronously in the order of their sessions
+func (n *Node) dispatch(ctx context.Context, s *stream, m *clusterpb.StreamMessage) {
+	var sid int64
+	switch {
+	case m.Request != nil:
+		sid = m.Request.SessionId
+	case m.Notify != nil:
+		sid = m.Notify.SessionId
+	case m.SessionClosed != nil:
+		sid = m.SessionClosed.SessionId
+	default:
+		n.handleStream(ctx, s, m)
+		return
+	}
+	async := m.Request != nil || m.Notify != nil
+	s.run(sid, async, func() { n.handleStream(ctx, s, m) })
+}
+
+// handleStream handles the message received from the stream
+func (n *Node) handleStream(ctx context.Context, s *stream, m *clusterpb.StreamMessage) {
+	var err error
+	switch {
+	case m.Request != nil:
+		if timeout, e := time.ParseDuration(m.Request.Metadata[timeoutKey]); e == nil {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		if _, err = n.HandleRequest(ctx, m.Request); err != nil {
+			// Respond the error as the unary call does
+			n.streamError(s, m.Request.SessionId, m.Request.Id, err)
+		}
+	case m.Notify != nil:
+		_, err = n.HandleNotify(ctx, m.Notify)
+	case m.SessionClosed != nil:
+		_, err = n.SessionClosed(ctx, m.SessionClosed)
+	case m.Response != nil:
+		_, err = n.HandleResponse(ctx, m.Response)
+	case m.Push != nil:
+		_, err = n.HandlePush(ctx, m.Push)
+	case m.Multicast != nil:
+		_, err = n.HandleMulticast(ctx, m.Multicast)
+	case m.Kick != nil:
+		_, err = n.KickSession(ctx, m.Kick)
+	case m.Close != nil:
+		_, err = n.CloseSession(ctx, m.Close)
+	case m.Rebind != nil:
+		_, err = n.RebindSession(ctx, m.Rebind)
+	case m.Bind != nil:
+		_, err = n.BindSession(ctx, m.Bind)
+	}
+	if err != nil {
+		log.Println(fmt.Sprintf("Handle stream message from %s error: %+v", s.addr, err))
+	}
+}
+
+// streamError responds the error of the request received from the stream, the
+// details of the non-structured errors are hidden from the client
+func (n *Node) streamError(s *stream, sid int64, mid uint64, cause error) {
+	data, err := message.EncodeError(clientError(cause))
+	if err != nil {
+		log.Println("Encode error failed", err)
+		return
+	}
+	response := &clusterpb.ResponseMessage{SessionId: sid, Id: mid, Data: data, Error: true}
+	if err := s.Send(&clusterpb.StreamMessage{Response: response}); err != nil {
+		log.Println("Respond error through stream failed", err)
+	}
+}
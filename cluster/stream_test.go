@@ -0,0 +1,177 @@
+package cluster_test
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/lonng/nano/benchmark/testdata"
+	"github.com/lonng/nano/client"
+	"github.com/lonng/nano/cluster"
+	"github.com/lonng/nano/component"
+	"github.com/lonng/nano/internal/message"
+	"github.com/lonng/nano/session"
+	. "github.com/pingcap/check"
+)
+
+type OrderComponent struct {
+	component.Base
+	mu       sync.Mutex
+	contents []string
+}
+
+func (c *OrderComponent) Order(s *session.Session, ping *testdata.Ping) error {
+	c.mu.Lock()
+	c.contents = append(c.contents, ping.Content)
+	c.mu.Unlock()
+	return nil
+}
+
+var releases = make(chan func(), 1)
+
+func (c *GameComponent) Hold(s *session.Session, _ *testdata.Ping) error {
+	releases <- cluster.HoldSession(s)
+	return s.Response(&testdata.Pong{Content: "held"})
+}
+
+func (s *nodeSuite) TestForwardStream(c *C) {
+	masterNode := startNode(c, cluster.Options{IsMaster: true})
+	defer masterNode.Shutdown()
+
+	gateNode := startNode(c, cluster.Options{
+		AdvertiseAddr:  masterNode.ServiceAddr,
+		ClientAddr:     "127.0.0.1:0",
+		RequestTimeout: 300 * time.Millisecond,
+		ForwardStream:  true,
+	})
+	defer gateNode.Shutdown()
+
+	gameComps := &component.Components{}
+	gameComps.Register(&GameComponent{})
+	order := &OrderComponent{}
+	gameComps.Register(order)
+	gameNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		Components:    gameComps,
+	})
+	defer gameNode.Shutdown()
+
+	kicked := make(chan client.KickReason, 1)
+	cli, err := client.Dial(context.Background(), gateNode.ClientAddr, client.WithKickHandler(func(reason client.KickReason) {
+		kicked <- reason
+	}))
+	c.Assert(err, IsNil)
+	defer cli.Close()
+	var mu sync.Mutex
+	var pushed []string
+	push := func(data []byte) {
+		pong := &testdata.Pong{}
+		c.Assert(cli.Unmarshal(data, pong), IsNil)
+		mu.Lock()
+		pushed = append(pushed, pong.Content)
+		mu.Unlock()
+	}
+	cli.On("onBurst", push)
+	cli.On("test", push)
+
+	// The notifies are handled in order, and the pushes arrive before the response
+	var expected []string
+	for i := 0; i < 50; i++ {
+		content := fmt.Sprintf("notify %d", i)
+		expected = append(expected, content)
+		c.Assert(cli.Notify("OrderComponent.Order", &testdata.Ping{Content: content}), IsNil)
+	}
+	pong := &testdata.Pong{}
+	c.Assert(cli.Request(context.Background(), "GameComponent.Burst", &testdata.Ping{Content: "burst"}, pong), IsNil)
+	c.Assert(pong.Content, Equals, "done")
+	order.mu.Lock()
+	c.Assert(order.contents, DeepEquals, expected)
+	order.mu.Unlock()
+	mu.Lock()
+	c.Assert(pushed, HasLen, 10)
+	pushed = nil
+	mu.Unlock()
+
+	// The error of the backend is responded through the stream
+	err = cli.Request(context.Background(), "GameComponent.Unknown", &testdata.Ping{Content: "ping"}, pong)
+	e, ok := err.(*client.Error)
+	c.Assert(ok, IsTrue)
+	c.Assert(e.Code, Equals, message.ErrCodeInternal)
+	c.Assert(e.Message, Equals, "internal error")
+
+	// The deadline of the gate is propagated in the metadata
+	go cli.Request(context.Background(), "GameComponent.Wait", &testdata.Ping{Content: "ping"}, pong)
+	<-waitStarted
+	select {
+	case err := <-waitErr:
+		c.Assert(err, Equals, context.DeadlineExceeded)
+	case <-time.After(time.Second):
+		c.Fatal("deadline is not propagated")
+	}
+
+	// The kick is sent after the push
+	c.Assert(cli.Notify("GameComponent.Kick", &testdata.Ping{Content: "kicked"}), IsNil)
+	select {
+	case reason := <-kicked:
+		c.Assert(reason.Message, Equals, "kicked")
+	case <-time.After(time.Second):
+		c.Fatal("kick not received")
+	}
+	mu.Lock()
+	c.Assert(pushed, DeepEquals, []string{"bye"})
+	mu.Unlock()
+}
+
+func (s *nodeSuite) TestForwardStreamSessions(c *C) {
+	masterNode := startNode(c, cluster.Options{IsMaster: true})
+	defer masterNode.Shutdown()
+
+	gateNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		ClientAddr:    "127.0.0.1:0",
+		ForwardStream: true,
+	})
+	defer gateNode.Shutdown()
+
+	gameComps := &component.Components{}
+	gameComps.Register(&GameComponent{})
+	gameNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		Components:    gameComps,
+	})
+	defer gameNode.Shutdown()
+
+	held, err := client.Dial(context.Background(), gateNode.ClientAddr)
+	c.Assert(err, IsNil)
+	defer held.Close()
+	other, err := client.Dial(context.Background(), gateNode.ClientAddr)
+	c.Assert(err, IsNil)
+	defer other.Close()
+
+	pong := &testdata.Pong{}
+	c.Assert(held.Request(context.Background(), "GameComponent.Hold", &testdata.Ping{}, pong), IsNil)
+	release := <-releases
+
+	// The messages of the held session wait without blocking the other sessions
+	done := make(chan error, 1)
+	go func() {
+		done <- held.Request(context.Background(), "GameComponent.Echo", &testdata.Ping{Content: "held"}, &testdata.Pong{})
+	}()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	c.Assert(other.Request(ctx, "GameComponent.Echo", &testdata.Ping{Content: "other"}, pong), IsNil)
+	select {
+	case <-done:
+		c.Fatal("message of the held session is handled")
+	default:
+	}
+
+	release()
+	select {
+	case err := <-done:
+		c.Assert(err, IsNil)
+	case <-time.After(time.Second):
+		c.Fatal("message of the released session is not handled")
+	}
+}
@@ -0,0 +1,77 @@
+package cluster_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/lonng/nano/benchmark/testdata"
+	"github.com/lonng/nano/cluster"
+	"github.com/lonng/nano/component"
+	"github.com/lonng/nano/session"
+	"github.com/lonng/nano/tracing"
+	. "github.com/pingcap/check"
+)
+
+func (c *GameComponent) Trace(ctx context.Context, s *session.Session, ping *testdata.Ping) (*testdata.Pong, error) {
+	return &testdata.Pong{Content: tracing.SpanFromContext(ctx).SpanContext().Traceparent()}, nil
+}
+
+func (s *nodeSuite) TestTracing(c *C) {
+	masterNode := startNode(c, cluster.Options{IsMaster: true})
+	defer masterNode.Shutdown()
+
+	gateExporter := tracing.NewMemoryExporter()
+	gateNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		ClientAddr:    "127.0.0.1:0",
+		TraceExporter: gateExporter,
+	})
+	defer gateNode.Shutdown()
+
+	gameExporter := tracing.NewMemoryExporter()
+	gameComps := &component.Components{}
+	gameComps.Register(&GameComponent{})
+	gameNode := startNode(c, cluster.Options{
+		AdvertiseAddr: masterNode.ServiceAddr,
+		Components:    gameComps,
+		TraceExporter: gameExporter,
+	})
+	defer gameNode.Shutdown()
+
+	client, _ := dialRaw(c, gateNode.ClientAddr, `{"sys":{}}`)
+	defer client.conn.Close()
+	pong := client.request(c, 1, "GameComponent.Trace", &testdata.Ping{Content: "ping"})
+
+	spans := func(exporter *tracing.MemoryExporter, n int) map[string]*tracing.Span {
+		waitFor(c, time.Second, func() bool { return len(exporter.Spans()) >= n })
+		named := map[string]*tracing.Span{}
+		for _, span := range exporter.Spans() {
+			named[span.Name] = span
+		}
+		return named
+	}
+	gateSpans := spans(gateExporter, 3)
+	gameSpans := spans(gameExporter, 1)
+
+	root := gateSpans["GameComponent.Trace"]
+	forward := gateSpans["forward GameComponent.Trace"]
+	response := gateSpans["response"]
+	handle := gameSpans["handle GameComponent.Trace"]
+	c.Assert(root, NotNil)
+	c.Assert(forward, NotNil)
+	c.Assert(response, NotNil)
+	c.Assert(handle, NotNil)
+
+	traceID := root.Context.TraceID
+	c.Assert(root.ParentID, Equals, tracing.SpanID{})
+	c.Assert(forward.Context.TraceID, Equals, traceID)
+	c.Assert(forward.ParentID, Equals, root.Context.SpanID)
+	c.Assert(forward.Attributes["nano.member"], Equals, gameNode.ServiceAddr)
+	c.Assert(handle.Context.TraceID, Equals, traceID)
+	c.Assert(handle.ParentID, Equals, forward.Context.SpanID)
+	c.Assert(response.Context.TraceID, Equals, traceID)
+	c.Assert(response.ParentID, Equals, handle.Context.SpanID)
+
+	// The handler observes its span through the context
+	c.Assert(pong.Content, Equals, handle.Context.Traceparent())
+}
@@ -21,6 +21,7 @@
 package component
 
 import (
+	"context"
 	"reflect"
 	"unicode"
 	"unicode/utf8"
@@ -32,6 +33,7 @@ var (
 	typeOfError   = reflect.TypeOf((*error)(nil)).Elem()
 	typeOfBytes   = reflect.TypeOf(([]byte)(nil))
 	typeOfSession = reflect.TypeOf(session.New(nil))
+	typeOfContext = reflect.TypeOf((*context.Context)(nil)).Elem()
 )
 
 func isExported(name string) bool {
@@ -56,22 +58,30 @@ func isHandlerMethod(method reflect.Method) bool {
 		return false
 	}
 
-	// Method needs three ins: receiver, *Session, []byte or pointer.
-	if mt.NumIn() != 3 {
-		return false
-	}
+	switch mt.NumIn() {
+	// Method needs three ins: receiver, *Session, []byte or pointer, and
+	// one outs: error
+	case 3:
+		if mt.NumOut() != 1 {
+			return false
+		}
+		return isSessionArg(mt.In(1)) && isDataArg(mt.In(2)) && mt.Out(0) == typeOfError
 
-	// Method needs one outs: error
-	if mt.NumOut() != 1 {
-		return false
+	// Method needs four ins: receiver, context.Context, *Session, []byte or
+	// pointer, and two outs: []byte or pointer, error
+	case 4:
+		if mt.NumOut() != 2 || mt.In(1) != typeOfContext {
+			return false
+		}
+		return isSessionArg(mt.In(2)) && isDataArg(mt.In(3)) && isDataArg(mt.Out(0)) && mt.Out(1) == typeOfError
 	}
+	return false
+}
 
-	if t1 := mt.In(1); t1.Kind() != reflect.Ptr || t1 != typeOfSession {
-		return false
-	}
+func isSessionArg(t reflect.Type) bool {
+	return t.Kind() == reflect.Ptr && t == typeOfSession
+}
 
-	if (mt.In(2).Kind() != reflect.Ptr && mt.In(2) != typeOfBytes) || mt.Out(0) != typeOfError {
-		return false
-	}
-	return true
+func isDataArg(t reflect.Type) bool {
+	return t.Kind() == reflect.Ptr || t == typeOfBytes
 }
@@ -25,6 +25,9 @@ type (
 		name      string              // component name
 		nameFunc  func(string) string // rename handler name
 		schedName string              // schedName name
+		scheduler string              // name of the named scheduler
+		actor     bool                // whether scheduled on the actors
+		actorKey  string              // key of the session data sharding the actors
 	}
 
 	// Option used to customize handler
@@ -52,3 +55,32 @@ func WithSchedulerName(name string) Option {
 		opt.schedName = name
 	}
 }
+
+// WithNamedScheduler schedules the handlers of the service on the named scheduler,
+// which runs on its own goroutine instead of the default scheduler, see
+// scheduler.Named
+func WithNamedScheduler(name string) Option {
+	return func(opt *options) {
+		opt.scheduler = name
+	}
+}
+
+// WithActorScheduler schedules the handlers of the service on the built-in actors:
+// the messages of a session run in order, and the messages of different sessions
+// run in parallel on a pool of workers
+func WithActorScheduler() Option {
+	return func(opt *options) {
+		opt.actor = true
+	}
+}
+
+// WithActorKey schedules the handlers of the service on the built-in actors like
+// WithActorScheduler, but the messages of the sessions sharing a value of key in
+// the session data, e.g. a room ID, run in order instead. The sessions without
+// the value fall back to be scheduled by session.
+func WithActorKey(key string) Option {
+	return func(opt *options) {
+		opt.actor = true
+		opt.actorKey = key
+	}
+}
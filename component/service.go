@@ -28,10 +28,11 @@ import (
 type (
 	//Handler represents a message.Message's handler's meta information.
 	Handler struct {
-		Receiver reflect.Value  // receiver of method
-		Method   reflect.Method // method stub
-		Type     reflect.Type   // arg type of method
-		IsRawArg bool           // whether the data need to unserialize
+		Receiver  reflect.Value  // receiver of method
+		Method    reflect.Method // method stub
+		Type      reflect.Type   // arg type of method
+		IsRawArg  bool           // whether the data need to unserialize
+		IsContext bool           // whether the method accepts a context and returns the response
 	}
 
 	// Service implements a specific service, some of it's methods will be
@@ -42,6 +43,9 @@ type (
 		Receiver  reflect.Value       // receiver of methods for the service
 		Handlers  map[string]*Handler // registered methods
 		SchedName string              // name of scheduler variable in session data
+		Scheduler string              // name of the named scheduler
+		Actor     bool                // whether scheduled on the actors
+		ActorKey  string              // key of the session data sharding the actors
 		Options   options             // options
 	}
 )
@@ -63,6 +67,9 @@ func NewService(comp Component, opts []Option) *Service {
 		s.Name = reflect.Indirect(s.Receiver).Type().Name()
 	}
 	s.SchedName = s.Options.schedName
+	s.Scheduler = s.Options.scheduler
+	s.Actor = s.Options.actor
+	s.ActorKey = s.Options.actorKey
 
 	return s
 }
@@ -75,15 +82,17 @@ func (s *Service) suitableHandlerMethods(typ reflect.Type) map[string]*Handler {
 		mt := method.Type
 		mn := method.Name
 		if isHandlerMethod(method) {
+			isContext := mt.NumIn() == 4
+			argType := mt.In(mt.NumIn() - 1)
 			raw := false
-			if mt.In(2) == typeOfBytes {
+			if argType == typeOfBytes {
 				raw = true
 			}
 			// rewrite handler name
 			if s.Options.nameFunc != nil {
 				mn = s.Options.nameFunc(mn)
 			}
-			methods[mn] = &Handler{Method: method, Type: mt.In(2), IsRawArg: raw}
+			methods[mn] = &Handler{Method: method, Type: argType, IsRawArg: raw, IsContext: isContext}
 		}
 	}
 	return methods
@@ -95,6 +104,10 @@ func (s *Service) suitableHandlerMethods(typ reflect.Type) map[string]*Handler {
 // - two arguments, both of exported type
 // - the first argument is *session.Session
 // - the second argument is []byte or a pointer
+// - returns an error
+// or alternatively:
+// - three arguments: context.Context, *session.Session, []byte or a pointer
+// - returns the response ([]byte or a pointer) and an error
 func (s *Service) ExtractHandler() error {
 	typeName := reflect.Indirect(s.Receiver).Type().Name()
 	if typeName == "" {
@@ -20,7 +20,11 @@
 
 package nano
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/lonng/nano/internal/message"
+)
 
 // Errors that could be occurred during message handling.
 var (
@@ -29,3 +33,21 @@ var (
 	ErrMemberNotFound     = errors.New("member not found in the group")
 	ErrSessionDuplication = errors.New("session has existed in the current group")
 )
+
+// ErrAppRunning is returned by Start if the application has been running
+var ErrAppRunning = errors.New("app is running")
+
+// Error represents a structured error, a handler returns it to fail the request,
+// and it will be responded to the client with the error flag set
+type Error = message.Error
+
+// Error codes of the errors responded by nano
+const (
+	ErrCodeNotFound = message.ErrCodeNotFound
+	ErrCodeInternal = message.ErrCodeInternal
+)
+
+// NewError returns a structured error with code and message
+func NewError(code int, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
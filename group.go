@@ -25,9 +25,9 @@ import (
 	"sync"
 	"sync/atomic"
 
+	"github.com/lonng/nano/cluster"
 	"github.com/lonng/nano/internal/env"
 	"github.com/lonng/nano/internal/log"
-	"github.com/lonng/nano/internal/message"
 	"github.com/lonng/nano/session"
 )
 
@@ -41,7 +41,9 @@ const (
 type SessionFilter func(*session.Session) bool
 
 // Group represents a session group which used to manage a number of
-// sessions, data send to the group will send to all session in it.
+// sessions, data send to the group will send to all session in it. The
+// sessions on a backend node may connect to different gates, the message
+// is sent to each gate in one RPC and delivered to its sessions by the gate.
 type Group struct {
 	mu       sync.RWMutex
 	status   int32                      // channel current status
@@ -99,17 +101,13 @@ func (c *Group) Members() []int64 {
 	return members
 }
 
-// Multicast  push  the message to the filtered clients
+// Multicast  push  the message to the filtered clients, the failures of pushing
+// to the clients are logged, and only the serialization error is returned
 func (c *Group) Multicast(route string, v interface{}, filter SessionFilter) error {
 	if c.isClosed() {
 		return ErrClosedGroup
 	}
 
-	data, err := message.Serialize(v)
-	if err != nil {
-		return err
-	}
-
 	if env.Debug {
 		log.Println(fmt.Sprintf("Multicast %s, Data=%+v", route, v))
 	}
@@ -117,29 +115,26 @@ func (c *Group) Multicast(route string, v interface{}, filter SessionFilter) err
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	var sessions []*session.Session
 	for _, s := range c.sessions {
-		if !filter(s) {
-			continue
-		}
-		if err = s.Push(route, data); err != nil {
-			log.Println(err.Error())
+		if filter(s) {
+			sessions = append(sessions, s)
 		}
 	}
-
-	return nil
+	err := cluster.MulticastValue(sessions, route, v)
+	if _, ok := err.(*cluster.MulticastError); ok {
+		return nil
+	}
+	return err
 }
 
-// Broadcast push  the message(s) to  all members
+// Broadcast push  the message(s) to  all members, a *cluster.MulticastError is
+// returned if pushing to some members failed
 func (c *Group) Broadcast(route string, v interface{}) error {
 	if c.isClosed() {
 		return ErrClosedGroup
 	}
 
-	data, err := message.Serialize(v)
-	if err != nil {
-		return err
-	}
-
 	if env.Debug {
 		log.Println(fmt.Sprintf("Broadcast %s, Data=%+v", route, v))
 	}
@@ -147,13 +142,12 @@ func (c *Group) Broadcast(route string, v interface{}) error {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
+	sessions := make([]*session.Session, 0, len(c.sessions))
 	for _, s := range c.sessions {
-		if err = s.Push(route, data); err != nil {
-			log.Println(fmt.Sprintf("Session push message error, ID=%d, UID=%d, Error=%s", s.ID(), s.UID(), err.Error()))
-		}
+		sessions = append(sessions, s)
 	}
 
-	return err
+	return cluster.MulticastValue(sessions, route, v)
 }
 
 // Contains check whether a UID is contained in current group or not
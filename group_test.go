@@ -1,9 +1,12 @@
 package nano
 
 import (
+	"errors"
 	"math/rand"
 	"testing"
 
+	"github.com/lonng/nano/cluster"
+	"github.com/lonng/nano/mock"
 	"github.com/lonng/nano/session"
 )
 
@@ -40,3 +43,30 @@ func TestChannel_Add(t *testing.T) {
 		t.Fail()
 	}
 }
+
+type failingEntity struct {
+	*mock.NetworkEntity
+}
+
+var errPush = errors.New("push failed")
+
+func (failingEntity) Push(string, interface{}) error {
+	return errPush
+}
+
+func TestChannel_PushError(t *testing.T) {
+	c := NewGroup("test_push_error")
+	c.Add(session.New(mock.NewNetworkEntity()))
+	c.Add(session.New(failingEntity{mock.NewNetworkEntity()}))
+
+	// The failures of multicast are only logged
+	if err := c.Multicast("test", []byte("data"), func(*session.Session) bool { return true }); err != nil {
+		t.Fatalf("expect multicast succeeded, got %v", err)
+	}
+
+	err := c.Broadcast("test", []byte("data"))
+	multicastErr, ok := err.(*cluster.MulticastError)
+	if !ok || len(multicastErr.Errors) != 1 || !errors.Is(err, errPush) {
+		t.Fatalf("expect the push error, got %v", err)
+	}
+}
@@ -21,49 +21,27 @@
 package nano
 
 import (
-	"fmt"
 	"os"
-	"os/signal"
 	"path/filepath"
-	"strings"
-	"sync/atomic"
-	"syscall"
-	"time"
+	"sync"
 
-	"github.com/lonng/nano/cluster"
-	"github.com/lonng/nano/component"
 	"github.com/lonng/nano/internal/env"
 	"github.com/lonng/nano/internal/log"
-	"github.com/lonng/nano/internal/runtime"
-	"github.com/lonng/nano/scheduler"
 )
 
-var running int32
-
 // VERSION returns current nano version
 var VERSION = "0.5.0"
 
 var (
-	// app represents the current server process
-	app = &struct {
-		name    string    // current application name
-		startAt time.Time // startup time
-	}{}
+	// apps represents the applications started by Listen
+	appsMu sync.Mutex
+	apps   = map[*App]struct{}{}
 )
 
 // Listen listens on the TCP network address addr
 // and then calls Serve with handler to handle requests
 // on incoming connections.
 func Listen(addr string, opts ...Option) {
-	if atomic.AddInt32(&running, 1) != 1 {
-		log.Println("Nano has running")
-		return
-	}
-
-	// application initialize
-	app.name = strings.TrimLeft(filepath.Base(os.Args[0]), "/")
-	app.startAt = time.Now()
-
 	// environment initialize
 	if wd, err := os.Getwd(); err != nil {
 		panic(err)
@@ -71,62 +49,26 @@ func Listen(addr string, opts ...Option) {
 		env.Wd, _ = filepath.Abs(wd)
 	}
 
-	opt := cluster.Options{
-		Components: &component.Components{},
-	}
-	for _, option := range opts {
-		option(&opt)
-	}
-
-	// Use listen address as client address in non-cluster mode
-	if !opt.IsMaster && opt.AdvertiseAddr == "" && opt.ClientAddr == "" {
-		log.Println("The current server running in singleton mode")
-		opt.ClientAddr = addr
-	}
+	app := New(addr, opts...)
+	appsMu.Lock()
+	apps[app] = struct{}{}
+	appsMu.Unlock()
 
-	// Set the retry interval to 3 secondes if doesn't set by user
-	if opt.RetryInterval == 0 {
-		opt.RetryInterval = time.Second * 3
+	if err := app.Listen(); err != nil {
+		log.Println("Nano server startup failed", err)
 	}
 
-	node := &cluster.Node{
-		Options:     opt,
-		ServiceAddr: addr,
-	}
-	err := node.Startup()
-	if err != nil {
-		log.Fatalf("Node startup failed: %v", err)
-	}
-	runtime.CurrentNode = node
-
-	if node.ClientAddr != "" {
-		log.Println(fmt.Sprintf("Startup *Nano gate server* %s, client address: %v, service address: %s",
-			app.name, node.ClientAddr, node.ServiceAddr))
-	} else {
-		log.Println(fmt.Sprintf("Startup *Nano backend server* %s, service address %s",
-			app.name, node.ServiceAddr))
-	}
-
-	go scheduler.Sched()
-	sg := make(chan os.Signal)
-	signal.Notify(sg, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGKILL, syscall.SIGTERM)
-
-	select {
-	case <-env.Die:
-		log.Println("The app will shutdown in a few seconds")
-	case s := <-sg:
-		log.Println("Nano server got signal", s)
-	}
-
-	log.Println("Nano server is stopping...")
-
-	node.Shutdown()
-	runtime.CurrentNode = nil
-	scheduler.Close()
-	atomic.StoreInt32(&running, 0)
+	appsMu.Lock()
+	delete(apps, app)
+	appsMu.Unlock()
 }
 
-// Shutdown send a signal to let 'nano' shutdown itself.
+// Shutdown send a signal to let the applications started by Listen shutdown
+// themselves.
 func Shutdown() {
-	close(env.Die)
+	appsMu.Lock()
+	defer appsMu.Unlock()
+	for app := range apps {
+		app.Shutdown()
+	}
 }
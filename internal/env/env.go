@@ -34,7 +34,6 @@ import (
 
 var (
 	Wd                 string                   // working path
-	Die                chan bool                // wait for end application
 	Heartbeat          time.Duration            // Heartbeat internal
 	CheckOrigin        func(*http.Request) bool // check origin when websocket enabled
 	Debug              bool                     // enable Debug
@@ -54,7 +53,6 @@ var (
 )
 
 func init() {
-	Die = make(chan bool)
 	Heartbeat = 30 * time.Second
 	Debug = false
 	CheckOrigin = func(_ *http.Request) bool { return true }
@@ -42,6 +42,7 @@ const (
 
 const (
 	msgRouteCompressMask = 0x01
+	msgErrorMask         = 0x20
 	msgTypeMask          = 0x07
 	msgRouteLengthMask   = 0xFF
 	msgHeadLength        = 0x02
@@ -77,6 +78,7 @@ type Message struct {
 	ID         uint64 // unique id, zero while notify mode
 	Route      string // route for locating service
 	Data       []byte // payload
+	Err        bool   // is an error response
 	compressed bool   // is message compressed
 }
 
@@ -116,8 +118,14 @@ func invalidType(t Type) bool {
 // | push     |----011-|<route>             |
 // ------------------------------------------
 // The figure above indicates that the bit does not affect the type of message.
+// The error flag(--1-----) is set in the response of a failed request, whose
+// payload is a JSON encoded Error.
 // See ref: https://github.com/lonnng/nano/blob/master/docs/communication_protocol.md
 func Encode(m *Message) ([]byte, error) {
+	return encode(m, routes)
+}
+
+func encode(m *Message, routes map[string]uint16) ([]byte, error) {
 	if invalidType(m.Type) {
 		return nil, ErrWrongMessageType
 	}
@@ -129,6 +137,9 @@ func Encode(m *Message) ([]byte, error) {
 	if compressed {
 		flag |= msgRouteCompressMask
 	}
+	if m.Err {
+		flag |= msgErrorMask
+	}
 	buf = append(buf, flag)
 
 	if m.Type == Request || m.Type == Response {
@@ -163,6 +174,10 @@ func Encode(m *Message) ([]byte, error) {
 // Decode unmarshal the bytes slice to a message
 // See ref: https://github.com/lonnng/nano/blob/master/docs/communication_protocol.md
 func Decode(data []byte) (*Message, error) {
+	return decode(data, codes)
+}
+
+func decode(data []byte, codes map[uint16]string) (*Message, error) {
 	if len(data) < msgHeadLength {
 		return nil, ErrInvalidMessage
 	}
@@ -170,6 +185,7 @@ func Decode(data []byte) (*Message, error) {
 	flag := data[0]
 	offset := 1
 	m.Type = Type((flag >> 1) & msgTypeMask)
+	m.Err = flag&msgErrorMask != 0
 
 	if invalidType(m.Type) {
 		return nil, ErrWrongMessageType
@@ -251,3 +267,36 @@ func GetDictionary() (map[string]uint16, bool) {
 	}
 	return routes, true
 }
+
+// Dictionary is a routes map which is used to compress route independently
+// of the global dictionary, e.g. the dictionary received by a client
+type Dictionary struct {
+	routes map[string]uint16 // route map to code
+	codes  map[uint16]string // code map to route
+}
+
+// NewDictionary returns a Dictionary of the routes map
+func NewDictionary(dict map[string]uint16) *Dictionary {
+	d := &Dictionary{
+		routes: make(map[string]uint16, len(dict)),
+		codes:  make(map[uint16]string, len(dict)),
+	}
+	for route, code := range dict {
+		r := strings.TrimSpace(route)
+		d.routes[r] = code
+		d.codes[code] = r
+	}
+	return d
+}
+
+// Encode marshals message to binary format with the routes compressed by
+// the dictionary
+func (d *Dictionary) Encode(m *Message) ([]byte, error) {
+	return encode(m, d.routes)
+}
+
+// Decode unmarshal the bytes slice to a message with the routes compressed by
+// the dictionary
+func (d *Dictionary) Decode(data []byte) (*Message, error) {
+	return decode(data, d.codes)
+}
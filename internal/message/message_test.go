@@ -161,4 +161,60 @@ func TestEncode(t *testing.T) {
 	if !reflect.DeepEqual(m8, dm8) {
 		t.Error("not equal")
 	}
+	data, err := EncodeError(&Error{Code: ErrCodeInternal, Message: "internal error"})
+	if err != nil {
+		t.Error(err.Error())
+	}
+	m9 := &Message{
+		Type: Response,
+		ID:   100,
+		Data: data,
+		Err:  true,
+	}
+	em9, err := m9.Encode()
+	if err != nil {
+		t.Error(err.Error())
+	}
+	dm9, err := Decode(em9)
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	if !reflect.DeepEqual(m9, dm9) {
+		t.Error("not equal")
+	}
+	e, err := DecodeError(dm9.Data)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	if e.Code != ErrCodeInternal || e.Message != "internal error" {
+		t.Error("not equal")
+	}
+}
+
+func TestDictionary(t *testing.T) {
+	d := NewDictionary(map[string]uint16{"dict.test.push": 200})
+	m1 := &Message{
+		Type:       Push,
+		Route:      "dict.test.push",
+		Data:       []byte(`hello world`),
+		compressed: true,
+	}
+	em1, err := d.Encode(m1)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	dm1, err := d.Decode(em1)
+	if err != nil {
+		t.Error(err.Error())
+	}
+
+	if !reflect.DeepEqual(m1, dm1) {
+		t.Error("not equal")
+	}
+
+	// The global dictionary is not aware of the route
+	if _, err := Decode(em1); err != ErrRouteInfoNotFound {
+		t.Error("route should not be found in global dictionary")
+	}
 }
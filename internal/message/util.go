@@ -20,13 +20,21 @@
 
 package message
 
-import "github.com/lonng/nano/internal/env"
+import (
+	"github.com/lonng/nano/internal/env"
+	"github.com/lonng/nano/serialize"
+)
 
 func Serialize(v interface{}) ([]byte, error) {
+	return SerializeWith(env.Serializer, v)
+}
+
+// SerializeWith is like Serialize, but marshals v by the specified serializer
+func SerializeWith(serializer serialize.Serializer, v interface{}) ([]byte, error) {
 	if data, ok := v.([]byte); ok {
 		return data, nil
 	}
-	data, err := env.Serializer.Marshal(v)
+	data, err := serializer.Marshal(v)
 	if err != nil {
 		return nil, err
 	}
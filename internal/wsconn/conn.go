@@ -18,7 +18,7 @@
 // OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
 // SOFTWARE.
 
-package cluster
+package wsconn
 
 import (
 	"io"
@@ -28,33 +28,32 @@ import (
 	"github.com/gorilla/websocket"
 )
 
-// wsConn is an adapter to t.Conn, which implements all t.Conn
+// Conn is an adapter to net.Conn, which implements all net.Conn
 // interface base on *websocket.Conn
-type wsConn struct {
+type Conn struct {
 	conn   *websocket.Conn
 	typ    int // message type
 	reader io.Reader
 }
 
-// newWSConn return an initialized *wsConn
-func newWSConn(conn *websocket.Conn) (*wsConn, error) {
-	c := &wsConn{conn: conn}
-
-	t, r, err := conn.NextReader()
-	if err != nil {
-		return nil, err
-	}
-
-	c.typ = t
-	c.reader = r
-
-	return c, nil
+// New return an initialized *Conn
+func New(conn *websocket.Conn) *Conn {
+	return &Conn{conn: conn}
 }
 
 // Read reads data from the connection.
 // Read can be made to time out and return an Error with Timeout() == true
 // after a fixed time limit; see SetDeadline and SetReadDeadline.
-func (c *wsConn) Read(b []byte) (int, error) {
+func (c *Conn) Read(b []byte) (int, error) {
+	if c.reader == nil {
+		t, r, err := c.conn.NextReader()
+		if err != nil {
+			return 0, err
+		}
+		c.typ = t
+		c.reader = r
+	}
+
 	n, err := c.reader.Read(b)
 	if err != nil && err != io.EOF {
 		return n, err
@@ -72,7 +71,7 @@ func (c *wsConn) Read(b []byte) (int, error) {
 // Write writes data to the connection.
 // Write can be made to time out and return an Error with Timeout() == true
 // after a fixed time limit; see SetDeadline and SetWriteDeadline.
-func (c *wsConn) Write(b []byte) (int, error) {
+func (c *Conn) Write(b []byte) (int, error) {
 	err := c.conn.WriteMessage(websocket.BinaryMessage, b)
 	if err != nil {
 		return 0, err
@@ -83,17 +82,17 @@ func (c *wsConn) Write(b []byte) (int, error) {
 
 // Close closes the connection.
 // Any blocked Read or Write operations will be unblocked and return errors.
-func (c *wsConn) Close() error {
+func (c *Conn) Close() error {
 	return c.conn.Close()
 }
 
 // LocalAddr returns the local network address.
-func (c *wsConn) LocalAddr() net.Addr {
+func (c *Conn) LocalAddr() net.Addr {
 	return c.conn.LocalAddr()
 }
 
 // RemoteAddr returns the remote network address.
-func (c *wsConn) RemoteAddr() net.Addr {
+func (c *Conn) RemoteAddr() net.Addr {
 	return c.conn.RemoteAddr()
 }
 
@@ -112,7 +111,7 @@ func (c *wsConn) RemoteAddr() net.Addr {
 // the deadline after successful Read or Write calls.
 //
 // A zero value for t means I/O operations will not time out.
-func (c *wsConn) SetDeadline(t time.Time) error {
+func (c *Conn) SetDeadline(t time.Time) error {
 	if err := c.conn.SetReadDeadline(t); err != nil {
 		return err
 	}
@@ -123,7 +122,7 @@ func (c *wsConn) SetDeadline(t time.Time) error {
 // SetReadDeadline sets the deadline for future Read calls
 // and any currently-blocked Read call.
 // A zero value for t means Read will not time out.
-func (c *wsConn) SetReadDeadline(t time.Time) error {
+func (c *Conn) SetReadDeadline(t time.Time) error {
 	return c.conn.SetReadDeadline(t)
 }
 
@@ -132,6 +131,6 @@ func (c *wsConn) SetReadDeadline(t time.Time) error {
 // Even if write times out, it may return n > 0, indicating that
 // some of the data was successfully written.
 // A zero value for t means Write will not time out.
-func (c *wsConn) SetWriteDeadline(t time.Time) error {
+func (c *Conn) SetWriteDeadline(t time.Time) error {
 	return c.conn.SetWriteDeadline(t)
 }
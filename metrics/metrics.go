@@ -0,0 +1,350 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// DefBuckets are the default buckets of histogram, which are tailored to
+// measure the latency in seconds
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+type (
+	// Collector writes the samples of a metric in the text exposition format
+	Collector interface {
+		Collect(w io.Writer) error
+	}
+
+	// desc describes a metric family
+	desc struct {
+		name       string
+		help       string
+		typ        string
+		labelNames []string
+	}
+
+	// vec holds the series of a metric family indexed by label values
+	vec struct {
+		desc
+		mu     sync.RWMutex
+		series map[string]interface{}
+		newFn  func() interface{}
+	}
+
+	// Counter is a cumulative metric which only goes up
+	Counter struct{ vec *vec }
+
+	// Gauge is a metric which can go up and down
+	Gauge struct{ vec *vec }
+
+	// GaugeFunc is a gauge whose value is retrieved by fn on collection
+	GaugeFunc struct {
+		desc
+		fn func() float64
+	}
+
+	// Histogram samples observations in configurable buckets
+	Histogram struct {
+		vec     *vec
+		buckets []float64
+	}
+
+	value struct{ bits uint64 }
+
+	histogram struct {
+		counts []uint64 // non-cumulative counts of buckets
+		count  uint64
+		sum    value
+	}
+)
+
+func (v *value) add(delta float64) {
+	for {
+		old := atomic.LoadUint64(&v.bits)
+		n := math.Float64bits(math.Float64frombits(old) + delta)
+		if atomic.CompareAndSwapUint64(&v.bits, old, n) {
+			return
+		}
+	}
+}
+
+func (v *value) set(f float64) {
+	atomic.StoreUint64(&v.bits, math.Float64bits(f))
+}
+
+func (v *value) get() float64 {
+	return math.Float64frombits(atomic.LoadUint64(&v.bits))
+}
+
+func newVec(d desc, newFn func() interface{}) *vec {
+	return &vec{desc: d, series: map[string]interface{}{}, newFn: newFn}
+}
+
+func (v *vec) with(labelValues []string) interface{} {
+	if len(labelValues) != len(v.labelNames) {
+		panic(fmt.Sprintf("metrics: %s expects %d label values, got %d", v.name, len(v.labelNames), len(labelValues)))
+	}
+	key := strings.Join(labelValues, "\xff")
+	v.mu.RLock()
+	s, found := v.series[key]
+	v.mu.RUnlock()
+	if found {
+		return s
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	if s, found := v.series[key]; found {
+		return s
+	}
+	s = v.newFn()
+	v.series[key] = s
+	return s
+}
+
+// each iterates the series in the order of label values
+func (v *vec) each(fn func(labelValues []string, s interface{}) error) error {
+	v.mu.RLock()
+	keys := make([]string, 0, len(v.series))
+	for key := range v.series {
+		keys = append(keys, key)
+	}
+	v.mu.RUnlock()
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		v.mu.RLock()
+		s := v.series[key]
+		v.mu.RUnlock()
+		var labelValues []string
+		if len(v.labelNames) > 0 {
+			labelValues = strings.Split(key, "\xff")
+		}
+		if err := fn(labelValues, s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *desc) header(w io.Writer) error {
+	_, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", d.name, escapeHelp(d.help), d.name, d.typ)
+	return err
+}
+
+// NewCounter returns a counter partitioned by the label names
+func NewCounter(name, help string, labelNames ...string) *Counter {
+	d := desc{name: name, help: help, typ: "counter", labelNames: labelNames}
+	return &Counter{vec: newVec(d, func() interface{} { return &value{} })}
+}
+
+// Inc increments the counter of the label values by 1
+func (c *Counter) Inc(labelValues ...string) {
+	c.Add(1, labelValues...)
+}
+
+// Add adds delta to the counter of the label values, delta must not be negative
+func (c *Counter) Add(delta float64, labelValues ...string) {
+	if delta < 0 {
+		panic(fmt.Sprintf("metrics: counter %s cannot decrease", c.vec.name))
+	}
+	c.vec.with(labelValues).(*value).add(delta)
+}
+
+// Value returns the counter of the label values
+func (c *Counter) Value(labelValues ...string) float64 {
+	return c.vec.with(labelValues).(*value).get()
+}
+
+// Collect implements the Collector interface
+func (c *Counter) Collect(w io.Writer) error {
+	return collectValues(w, c.vec)
+}
+
+// NewGauge returns a gauge partitioned by the label names
+func NewGauge(name, help string, labelNames ...string) *Gauge {
+	d := desc{name: name, help: help, typ: "gauge", labelNames: labelNames}
+	return &Gauge{vec: newVec(d, func() interface{} { return &value{} })}
+}
+
+// Set sets the gauge of the label values
+func (g *Gauge) Set(v float64, labelValues ...string) {
+	g.vec.with(labelValues).(*value).set(v)
+}
+
+// Add adds delta to the gauge of the label values
+func (g *Gauge) Add(delta float64, labelValues ...string) {
+	g.vec.with(labelValues).(*value).add(delta)
+}
+
+// Inc increments the gauge of the label values by 1
+func (g *Gauge) Inc(labelValues ...string) {
+	g.Add(1, labelValues...)
+}
+
+// Dec decrements the gauge of the label values by 1
+func (g *Gauge) Dec(labelValues ...string) {
+	g.Add(-1, labelValues...)
+}
+
+// Value returns the gauge of the label values
+func (g *Gauge) Value(labelValues ...string) float64 {
+	return g.vec.with(labelValues).(*value).get()
+}
+
+// Collect implements the Collector interface
+func (g *Gauge) Collect(w io.Writer) error {
+	return collectValues(w, g.vec)
+}
+
+// NewGaugeFunc returns a gauge whose value is retrieved by fn on collection
+func NewGaugeFunc(name, help string, fn func() float64) *GaugeFunc {
+	return &GaugeFunc{desc: desc{name: name, help: help, typ: "gauge"}, fn: fn}
+}
+
+// Collect implements the Collector interface
+func (g *GaugeFunc) Collect(w io.Writer) error {
+	if err := g.header(w); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(w, "%s %s\n", g.name, formatFloat(g.fn()))
+	return err
+}
+
+// NewHistogram returns a histogram partitioned by the label names, the
+// buckets are the upper bounds in increasing order, DefBuckets is used if
+// buckets is empty
+func NewHistogram(name, help string, buckets []float64, labelNames ...string) *Histogram {
+	if len(buckets) == 0 {
+		buckets = DefBuckets
+	}
+	if !sort.Float64sAreSorted(buckets) {
+		panic(fmt.Sprintf("metrics: buckets of histogram %s are not sorted", name))
+	}
+	d := desc{name: name, help: help, typ: "histogram", labelNames: labelNames}
+	h := &Histogram{buckets: buckets}
+	h.vec = newVec(d, func() interface{} {
+		return &histogram{counts: make([]uint64, len(buckets))}
+	})
+	return h
+}
+
+// Observe adds an observation to the histogram of the label values
+func (h *Histogram) Observe(v float64, labelValues ...string) {
+	s := h.vec.with(labelValues).(*histogram)
+	if i := sort.SearchFloat64s(h.buckets, v); i < len(h.buckets) {
+		atomic.AddUint64(&s.counts[i], 1)
+	}
+	s.sum.add(v)
+	atomic.AddUint64(&s.count, 1)
+}
+
+// Count returns the number of observations of the label values
+func (h *Histogram) Count(labelValues ...string) uint64 {
+	return atomic.LoadUint64(&h.vec.with(labelValues).(*histogram).count)
+}
+
+// Collect implements the Collector interface
+func (h *Histogram) Collect(w io.Writer) error {
+	if err := h.vec.header(w); err != nil {
+		return err
+	}
+	names := append(append([]string{}, h.vec.labelNames...), "le")
+	return h.vec.each(func(labelValues []string, s interface{}) error {
+		hist := s.(*histogram)
+		count := atomic.LoadUint64(&hist.count)
+		var cumulative uint64
+		for i, bound := range h.buckets {
+			cumulative += atomic.LoadUint64(&hist.counts[i])
+			lvs := append(append([]string{}, labelValues...), formatFloat(bound))
+			if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n", h.vec.name, formatLabels(names, lvs), cumulative); err != nil {
+				return err
+			}
+		}
+		lvs := append(append([]string{}, labelValues...), "+Inf")
+		if _, err := fmt.Fprintf(w, "%s_bucket%s %d\n", h.vec.name, formatLabels(names, lvs), count); err != nil {
+			return err
+		}
+		labels := formatLabels(h.vec.labelNames, labelValues)
+		if _, err := fmt.Fprintf(w, "%s_sum%s %s\n", h.vec.name, labels, formatFloat(hist.sum.get())); err != nil {
+			return err
+		}
+		_, err := fmt.Fprintf(w, "%s_count%s %d\n", h.vec.name, labels, count)
+		return err
+	})
+}
+
+func collectValues(w io.Writer, v *vec) error {
+	if err := v.header(w); err != nil {
+		return err
+	}
+	return v.each(func(labelValues []string, s interface{}) error {
+		_, err := fmt.Fprintf(w, "%s%s %s\n", v.name, formatLabels(v.labelNames, labelValues), formatFloat(s.(*value).get()))
+		return err
+	})
+}
+
+func formatLabels(names, values []string) string {
+	if len(names) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(name)
+		b.WriteString(`="`)
+		b.WriteString(escapeLabel(values[i]))
+		b.WriteByte('"')
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	case math.IsNaN(f):
+		return "NaN"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+var (
+	helpEscaper  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string  { return helpEscaper.Replace(s) }
+func escapeLabel(s string) string { return labelEscaper.Replace(s) }
@@ -0,0 +1,60 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRegistry(t *testing.T) {
+	counter := NewCounter("test_requests_total", "Number of requests.", "route")
+	gauge := NewGauge("test_sessions", "Number of sessions.")
+	gaugeFunc := NewGaugeFunc("test_queue_depth", "Depth of queue.", func() float64 { return 3 })
+	histogram := NewHistogram("test_duration_seconds", "Latency.", []float64{0.1, 1}, "route")
+
+	counter.Inc("b")
+	counter.Add(2, `a"\`)
+	gauge.Inc()
+	gauge.Inc()
+	gauge.Dec()
+	histogram.Observe(0.05, "a")
+	histogram.Observe(0.5, "a")
+	histogram.Observe(5, "a")
+
+	r := NewRegistry()
+	r.Register(counter, gauge, gaugeFunc, histogram)
+	buf := &bytes.Buffer{}
+	if err := r.Write(buf); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `# HELP test_requests_total Number of requests.
+# TYPE test_requests_total counter
+test_requests_total{route="a\"\\"} 2
+test_requests_total{route="b"} 1
+# HELP test_sessions Number of sessions.
+# TYPE test_sessions gauge
+test_sessions 1
+# HELP test_queue_depth Depth of queue.
+# TYPE test_queue_depth gauge
+test_queue_depth 3
+# HELP test_duration_seconds Latency.
+# TYPE test_duration_seconds histogram
+test_duration_seconds_bucket{route="a",le="0.1"} 1
+test_duration_seconds_bucket{route="a",le="1"} 2
+test_duration_seconds_bucket{route="a",le="+Inf"} 3
+test_duration_seconds_sum{route="a"} 5.55
+test_duration_seconds_count{route="a"} 3
+`
+	if buf.String() != expected {
+		t.Fatalf("unexpected exposition:\n%s", buf.String())
+	}
+}
+
+func TestLabelMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expect panic")
+		}
+	}()
+	NewCounter("test_total", "", "route").Inc()
+}
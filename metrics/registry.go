@@ -0,0 +1,95 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package metrics
+
+import (
+	"bufio"
+	"io"
+	"net/http"
+	"sync"
+)
+
+// ContentType is the content type of the text exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Registry holds the collectors which are exposed together
+type Registry struct {
+	mu         sync.RWMutex
+	collectors []Collector
+}
+
+// DefaultRegistry is the registry of the application metrics, which is exposed by
+// every node in the process along with the built-in metrics of the node
+var DefaultRegistry = NewRegistry()
+
+// NewRegistry returns an empty registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds the collectors to the registry
+func (r *Registry) Register(cs ...Collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, cs...)
+}
+
+// Write writes the samples of all collectors in the text exposition format
+func (r *Registry) Write(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	if err := r.Collect(bw); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// Collect implements the Collector interface, so that a registry could be
+// registered to another one to be exposed together
+func (r *Registry) Collect(w io.Writer) error {
+	r.mu.RLock()
+	cs := append([]Collector{}, r.collectors...)
+	r.mu.RUnlock()
+
+	for _, c := range cs {
+		if err := c.Collect(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Handler returns a http.Handler which exposes the samples of the registry
+func (r *Registry) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		r.Write(w)
+	})
+}
+
+// Register adds the collectors to the default registry
+func Register(cs ...Collector) {
+	DefaultRegistry.Register(cs...)
+}
+
+// Handler returns a http.Handler which exposes the default registry
+func Handler() http.Handler {
+	return DefaultRegistry.Handler()
+}
@@ -21,8 +21,11 @@
 package mock
 
 import (
+	"context"
 	"fmt"
 	"net"
+
+	"github.com/lonng/nano/session"
 )
 
 // NetAddr mock the net.Addr interface
@@ -46,6 +49,7 @@ type NetworkEntity struct {
 	responses []interface{}
 	msgmap    map[uint64]interface{}
 	rpcCall   []message
+	kicks     []session.KickReason
 }
 
 // NewNetworkEntity returns an mock network entity
@@ -61,6 +65,12 @@ func (n *NetworkEntity) RPC(route string, v interface{}) error {
 	return nil
 }
 
+// Call implements the session.Caller interface
+func (n *NetworkEntity) Call(_ context.Context, route string, v, _ interface{}) error {
+	n.rpcCall = append(n.rpcCall, message{route: route, data: v})
+	return nil
+}
+
 // Push implements the session.NetworkEntity interface
 func (n *NetworkEntity) Push(route string, v interface{}) error {
 	n.messages = append(n.messages, message{route: route, data: v})
@@ -88,6 +98,12 @@ func (n *NetworkEntity) ResponseMid(mid uint64, v interface{}) error {
 	return nil
 }
 
+// Kick implements the session.Kicker interface
+func (n *NetworkEntity) Kick(reason session.KickReason) error {
+	n.kicks = append(n.kicks, reason)
+	return nil
+}
+
 // Close implements the session.NetworkEntity interface
 func (n *NetworkEntity) Close() error {
 	return nil
@@ -106,6 +122,11 @@ func (n *NetworkEntity) LastResponse() interface{} {
 	return n.responses[len(n.responses)-1]
 }
 
+// Kicks returns the reasons the session was kicked
+func (n *NetworkEntity) Kicks() []session.KickReason {
+	return n.kicks
+}
+
 // FindResponseByMID returns the response respective the message id
 func (n *NetworkEntity) FindResponseByMID(mid uint64) interface{} {
 	return n.msgmap[mid]
@@ -8,11 +8,11 @@ import (
 	"github.com/lonng/nano/component"
 	"github.com/lonng/nano/internal/env"
 	"github.com/lonng/nano/internal/log"
-	"github.com/lonng/nano/internal/message"
 	"github.com/lonng/nano/pipeline"
+	"github.com/lonng/nano/scheduler"
 	"github.com/lonng/nano/serialize"
-	"github.com/lonng/nano/service"
 	"github.com/lonng/nano/session"
+	"github.com/lonng/nano/tracing"
 	"google.golang.org/grpc"
 )
 
@@ -42,6 +42,165 @@ func WithAdvertiseAddr(addr string, retryInterval ...time.Duration) Option {
 	}
 }
 
+// WithAdvertiseAddrs sets the addresses of all master nodes, the masters elect a leader
+// among themselves and cluster members fail over to the next address when the master
+// they connected to is unreachable or not the leader
+func WithAdvertiseAddrs(addrs []string, retryInterval ...time.Duration) Option {
+	return func(opt *cluster.Options) {
+		opt.AdvertiseAddrs = addrs
+		if len(retryInterval) > 0 {
+			opt.RetryInterval = retryInterval[0]
+		}
+	}
+}
+
+// WithElectionTimeout sets the duration of the lease granted by the leader master, a
+// follower master campaigns for the leadership once the lease expires
+func WithElectionTimeout(d time.Duration) Option {
+	return func(opt *cluster.Options) {
+		opt.ElectionTimeout = d
+	}
+}
+
+// WithDiscovery sets the membership backend, the node registers itself and watches
+// the other members through it instead of the master nodes
+func WithDiscovery(discovery cluster.Discovery) Option {
+	return func(opt *cluster.Options) {
+		opt.Discovery = discovery
+	}
+}
+
+// WithResumeWindow sets the duration a session is kept after its connection broken, the
+// client can resume the session on a new connection with the resume token returned in
+// the handshake response before the window elapsed
+func WithResumeWindow(d time.Duration) Option {
+	return func(opt *cluster.Options) {
+		opt.ResumeWindow = d
+	}
+}
+
+// WithRequestTimeout sets the deadline of the context passed to the context-aware handlers,
+// the deadline is propagated to the handlers on the remote members, but the cancellation
+// of the context on the gate is not
+func WithRequestTimeout(d time.Duration) Option {
+	return func(opt *cluster.Options) {
+		opt.RequestTimeout = d
+	}
+}
+
+// WithMetricsAddr sets the listen address of the HTTP server which exposes the metrics
+// on the /metrics endpoint in the Prometheus text exposition format
+func WithMetricsAddr(addr string) Option {
+	return func(opt *cluster.Options) {
+		opt.MetricsAddr = addr
+	}
+}
+
+// WithTraceExporter enables tracing the client messages through the gate and the backend
+// members, the trace context is propagated in the W3C traceparent format and the spans
+// are exported by exporter
+func WithTraceExporter(exporter tracing.Exporter) Option {
+	return func(opt *cluster.Options) {
+		opt.TraceExporter = exporter
+	}
+}
+
+// WithBatch enables batching the pushes and responses sent from a backend member to a
+// gate, the batch is flushed once its size reaches size bytes or the interval elapsed
+func WithBatch(interval time.Duration, size int) Option {
+	return func(opt *cluster.Options) {
+		opt.BatchInterval = interval
+		opt.BatchSize = size
+	}
+}
+
+// WithForwardStream enables forwarding the client messages from a gate to a backend
+// member through a long-lived bidirectional stream, the pushes and responses are sent
+// back through the stream too.
+//
+// Without the stream, every message is a unary call, and the messages keep their order
+// only if each call returns before the next one starts: the gate forwards the messages
+// of a session one by one, but the pushes and responses sent concurrently by a backend
+// may arrive at the client in any order. The stream delivers all messages between two
+// members in the order they are sent.
+func WithForwardStream() Option {
+	return func(opt *cluster.Options) {
+		opt.ForwardStream = true
+	}
+}
+
+// WithBalancer sets the balancer which selects the member serving the services, it
+// becomes the default balancer of all services if no service specified
+func WithBalancer(balancer cluster.Balancer, services ...string) Option {
+	return func(opt *cluster.Options) {
+		if len(services) == 0 {
+			opt.Balancer = balancer
+			return
+		}
+		if opt.Balancers == nil {
+			opt.Balancers = map[string]cluster.Balancer{}
+		}
+		for _, service := range services {
+			opt.Balancers[service] = balancer
+		}
+	}
+}
+
+// WithWeight sets the weight which current node advertises to the weighted balancers
+func WithWeight(weight int) Option {
+	return func(opt *cluster.Options) {
+		opt.Weight = weight
+	}
+}
+
+// WithLoadGauge adds a custom gauge to the load which current node reports to the
+// master, the loads of all members can be queried on every node
+func WithLoadGauge(name string, gauge func() float64) Option {
+	return func(opt *cluster.Options) {
+		if opt.LoadGauges == nil {
+			opt.LoadGauges = map[string]func() float64{}
+		}
+		opt.LoadGauges[name] = gauge
+	}
+}
+
+// WithDrain drains current node for at most timeout before shutdown, the sessions
+// forwarded from the gates are handed off via handoff and then rebound to the other
+// members by the gates, see cluster.Node.Drain
+func WithDrain(timeout time.Duration, handoff func(*session.Session) error) Option {
+	return func(opt *cluster.Options) {
+		opt.DrainTimeout = timeout
+		opt.SessionHandoff = handoff
+	}
+}
+
+// WithStateSerializer customizes the serializer of the session state migrated between
+// the members, which is gob by default
+func WithStateSerializer(serializer cluster.StateSerializer) Option {
+	return func(opt *cluster.Options) {
+		opt.StateSerializer = serializer
+	}
+}
+
+// WithForwardedValues sets the keys of the session values which are forwarded to the
+// backend nodes along with the UID, which must be set on both the gates and the
+// backends. The keys are replicated by the sessions of current node only, see
+// session.Replicate for the keys replicated by all nodes in the process.
+func WithForwardedValues(keys ...string) Option {
+	return func(opt *cluster.Options) {
+		opt.ForwardedValues = append(opt.ForwardedValues, keys...)
+	}
+}
+
+// WithActorWorkers sets the number of the workers running the actors, which schedule
+// the services registered with component.WithActorScheduler or
+// component.WithActorKey
+func WithActorWorkers(workers int) Option {
+	return func(opt *cluster.Options) {
+		opt.ActorWorkers = workers
+	}
+}
+
 // WithMemberAddr sets the listen address which is used to establish connection between
 // cluster members. Will select an available port automatically if no member address
 // setting and panic if no available port
@@ -60,8 +219,8 @@ func WithMaster() Option {
 
 // WithGrpcOptions sets the grpc dial options
 func WithGrpcOptions(opts ...grpc.DialOption) Option {
-	return func(_ *cluster.Options) {
-		env.GrpcOptions = append(env.GrpcOptions, opts...)
+	return func(opt *cluster.Options) {
+		opt.GrpcOptions = append(opt.GrpcOptions, opts...)
 	}
 }
 
@@ -74,35 +233,40 @@ func WithComponents(components *component.Components) Option {
 
 // WithHeartbeatInterval sets Heartbeat time interval
 func WithHeartbeatInterval(d time.Duration) Option {
-	return func(_ *cluster.Options) {
-		env.Heartbeat = d
+	return func(opt *cluster.Options) {
+		opt.Heartbeat = d
 	}
 }
 
 // WithCheckOriginFunc sets the function that check `Origin` in http headers
 func WithCheckOriginFunc(fn func(*http.Request) bool) Option {
 	return func(opt *cluster.Options) {
-		env.CheckOrigin = fn
+		opt.CheckOrigin = fn
 	}
 }
 
 // WithDebugMode let 'nano' to run under Debug mode.
 func WithDebugMode() Option {
-	return func(_ *cluster.Options) {
-		env.Debug = true
+	return func(opt *cluster.Options) {
+		opt.Debug = true
 	}
 }
 
 // SetDictionary sets routes map
 func WithDictionary(dict map[string]uint16) Option {
-	return func(_ *cluster.Options) {
-		message.SetDictionary(dict)
+	return func(opt *cluster.Options) {
+		if opt.Dictionary == nil {
+			opt.Dictionary = map[string]uint16{}
+		}
+		for route, code := range dict {
+			opt.Dictionary[route] = code
+		}
 	}
 }
 
 func WithWSPath(path string) Option {
-	return func(_ *cluster.Options) {
-		env.WSPath = path
+	return func(opt *cluster.Options) {
+		opt.WSPath = path
 	}
 }
 
@@ -122,7 +286,7 @@ func WithTimerPrecision(precision time.Duration) Option {
 // and UnMarshal handler payload
 func WithSerializer(serializer serialize.Serializer) Option {
 	return func(opt *cluster.Options) {
-		env.Serializer = serializer
+		opt.Serializer = serializer
 	}
 }
 
@@ -158,14 +322,15 @@ func WithLogger(l log.Logger) Option {
 // WithHandshakeValidator sets the function that Verify `handshake` data
 func WithHandshakeValidator(fn func(*session.Session, []byte) error) Option {
 	return func(opt *cluster.Options) {
-		env.HandshakeValidator = fn
+		opt.HandshakeValidator = fn
 	}
 }
 
-// WithNodeId set nodeId use snowflake nodeId generate sessionId, default: pid
+// WithNodeId set nodeId use snowflake nodeId generate sessionId, default: pid. The
+// session ids and the connection count are scoped to current node if set
 func WithNodeId(nodeId uint64) Option {
 	return func(opt *cluster.Options) {
-		service.ResetNodeId(nodeId)
+		opt.NodeID = nodeId
 	}
 }
 
@@ -175,3 +340,19 @@ func WithUnregisterCallback(fn func(member cluster.Member)) Option {
 		opt.UnregisterCallback = fn
 	}
 }
+
+// WithScheduler sets the scheduler which runs the handlers and the session lifetime
+// callbacks, the applications in a process share the default scheduler by default
+func WithScheduler(s *scheduler.Scheduler) Option {
+	return func(opt *cluster.Options) {
+		opt.Scheduler = s
+	}
+}
+
+// WithLifetime sets the lifetime callbacks for the sessions of current node, which
+// replace the callbacks of session.Lifetime
+func WithLifetime(lifetime *session.LifetimeManager) Option {
+	return func(opt *cluster.Options) {
+		opt.Lifetime = lifetime
+	}
+}
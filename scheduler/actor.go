@@ -0,0 +1,168 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scheduler
+
+import (
+	"runtime"
+	"sync"
+)
+
+// ActorPool schedules the tasks to the actors, each of which is a serialized
+// executor identified by a key, e.g. a session or a room. The tasks of the same
+// actor run in order, and the tasks of different actors run in parallel on a pool
+// of workers.
+type ActorPool struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	actors  map[interface{}]*actor // actors which have pending tasks
+	ready   []*actor               // actors waiting for a worker
+	pending int                    // number of pending tasks
+	closed  bool
+	wg      sync.WaitGroup
+}
+
+// actorBatch is the maximum number of the tasks an actor runs before yielding
+// the worker to the other ready actors
+const actorBatch = 64
+
+type actor struct {
+	key   interface{}
+	tasks []Task
+}
+
+// NewActorPool returns an ActorPool running on workers goroutines, which is the
+// number of CPUs if workers is not positive
+func NewActorPool(workers int) *ActorPool {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	p := &ActorPool{actors: map[interface{}]*actor{}}
+	p.cond = sync.NewCond(&p.mu)
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+	return p
+}
+
+// Schedule schedules the task to the actor of key, the key must be comparable.
+// The tasks scheduled after the pool closed are discarded.
+func (p *ActorPool) Schedule(key interface{}, task Task) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.closed {
+		return
+	}
+	a, found := p.actors[key]
+	if !found {
+		a = &actor{key: key}
+		p.actors[key] = a
+		p.ready = append(p.ready, a)
+		p.cond.Signal()
+	}
+	a.tasks = append(a.tasks, task)
+	p.pending++
+}
+
+// Scheduler returns the LocalScheduler which schedules the tasks to the actor of
+// key, e.g. to be stored in the session data for component.WithSchedulerName
+func (p *ActorPool) Scheduler(key interface{}) LocalScheduler {
+	return actorScheduler{pool: p, key: key}
+}
+
+// QueueDepth returns the number of tasks waiting to be scheduled
+func (p *ActorPool) QueueDepth() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.pending
+}
+
+// Close stops the pool after the pending tasks are done
+func (p *ActorPool) Close() {
+	p.mu.Lock()
+	if p.closed {
+		p.mu.Unlock()
+		return
+	}
+	p.closed = true
+	p.cond.Broadcast()
+	p.mu.Unlock()
+	p.wg.Wait()
+}
+
+// work runs the tasks of the ready actors until the pool closed
+func (p *ActorPool) work() {
+	defer p.wg.Done()
+	for {
+		p.mu.Lock()
+		for len(p.ready) == 0 && !p.closed {
+			p.cond.Wait()
+		}
+		if len(p.ready) == 0 {
+			p.mu.Unlock()
+			return
+		}
+		a := p.ready[0]
+		p.ready[0] = nil
+		p.ready = p.ready[1:]
+		p.mu.Unlock()
+
+		p.run(a)
+	}
+}
+
+// run runs a batch of the tasks of the actor, the actor is requeued if tasks
+// are left, so that a busy actor cannot starve the others. The actor is removed
+// once no task is left, so that the next task of the key will be run by a new
+// actor.
+func (p *ActorPool) run(a *actor) {
+	for i := 0; ; i++ {
+		p.mu.Lock()
+		if len(a.tasks) == 0 {
+			delete(p.actors, a.key)
+			p.mu.Unlock()
+			return
+		}
+		if i == actorBatch {
+			p.ready = append(p.ready, a)
+			p.cond.Signal()
+			p.mu.Unlock()
+			return
+		}
+		task := a.tasks[0]
+		a.tasks[0] = nil
+		a.tasks = a.tasks[1:]
+		p.pending--
+		p.mu.Unlock()
+
+		try(task)
+	}
+}
+
+type actorScheduler struct {
+	pool *ActorPool
+	key  interface{}
+}
+
+func (s actorScheduler) Schedule(task Task) {
+	s.pool.Schedule(s.key, task)
+}
@@ -0,0 +1,95 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestActorPool(t *testing.T) {
+	p := NewActorPool(4)
+
+	const actors, tasks = 8, 1000
+	var mu sync.Mutex
+	results := map[int][]int{}
+	for i := 0; i < tasks; i++ {
+		for key := 0; key < actors; key++ {
+			key, i := key, i
+			p.Schedule(key, func() {
+				mu.Lock()
+				results[key] = append(results[key], i)
+				mu.Unlock()
+			})
+		}
+	}
+	p.Close()
+
+	for key := 0; key < actors; key++ {
+		if len(results[key]) != tasks {
+			t.Fatalf("actor %d: expect %d tasks, got %d", key, tasks, len(results[key]))
+		}
+		for i, v := range results[key] {
+			if v != i {
+				t.Fatalf("actor %d: expect task %d, got %d", key, i, v)
+			}
+		}
+	}
+	if p.QueueDepth() != 0 {
+		t.Fatalf("expect no pending tasks, got %d", p.QueueDepth())
+	}
+
+	// The tasks scheduled after closed are discarded
+	var discarded int32
+	p.Schedule(0, func() { atomic.StoreInt32(&discarded, 1) })
+	if atomic.LoadInt32(&discarded) != 0 {
+		t.Fatalf("expect the task discarded")
+	}
+}
+
+func TestActorPoolParallel(t *testing.T) {
+	p := NewActorPool(2)
+	defer p.Close()
+
+	// The blocked actor does not block the others
+	block := make(chan struct{})
+	done := make(chan struct{})
+	p.Scheduler("blocked").Schedule(func() { <-block })
+	p.Scheduler("other").Schedule(func() { close(done) })
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatalf("expect the actors run in parallel")
+	}
+	close(block)
+}
+
+func TestActorPoolFairness(t *testing.T) {
+	p := NewActorPool(1)
+	defer p.Close()
+
+	// The busy actor yields the only worker after a batch of its tasks
+	block := make(chan struct{})
+	var busy int32
+	p.Schedule("busy", func() { <-block })
+	for i := 0; i < 10*actorBatch; i++ {
+		p.Schedule("busy", func() { atomic.AddInt32(&busy, 1) })
+	}
+	ran := make(chan int32, 1)
+	p.Schedule("other", func() { ran <- atomic.LoadInt32(&busy) })
+	close(block)
+	if n := <-ran; n >= 10*actorBatch {
+		t.Fatalf("expect the other actor run before the busy one finished, got %d", n)
+	}
+}
+
+func TestActorPoolIsCurrent(t *testing.T) {
+	p := NewActorPool(2)
+	defer p.Close()
+
+	ctx := WithCurrent(context.Background(), p.Scheduler("a"))
+	if !IsCurrent(ctx, p.Scheduler("a")) || IsCurrent(ctx, p.Scheduler("b")) {
+		t.Fatal("expect the context is used by actor a only")
+	}
+}
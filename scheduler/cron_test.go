@@ -0,0 +1,185 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCron(t *testing.T) {
+	valid := []string{
+		"* * * * *",
+		"*/5 * * * * *",
+		"0 4 * * *",
+		"0 0 * * MON",
+		"30 9 1,15 * mon-fri",
+		"0 0 12 ? JAN-MAR,dec 7",
+		"5/15 * * * *",
+		"CRON_TZ=Asia/Shanghai 0 4 * * *",
+		"TZ=UTC 0 0 * * 0",
+		"@daily",
+		"CRON_TZ=UTC @weekly",
+	}
+	for _, expr := range valid {
+		if _, err := ParseCron(expr); err != nil {
+			t.Fatalf("%q: %v", expr, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"* * * *",
+		"* * * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"1,,2 * * * *",
+		"* * * * foo",
+		"@fortnightly",
+		"CRON_TZ=Nowhere/Land 0 4 * * *",
+		"CRON_TZ=UTC",
+	}
+	for _, expr := range invalid {
+		if _, err := ParseCron(expr); err == nil {
+			t.Fatalf("%q: expect error", expr)
+		}
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	shanghai, err := time.LoadLocation("Asia/Shanghai")
+	if err != nil {
+		t.Skip(err)
+	}
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	santiago, err := time.LoadLocation("America/Santiago")
+	if err != nil {
+		t.Skip(err)
+	}
+
+	cases := []struct {
+		expr string
+		from time.Time
+		next time.Time
+	}{
+		{"TZ=UTC * * * * *", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 1, 0, 0, time.UTC)},
+		{"TZ=UTC * * * * * *", time.Date(2024, 1, 1, 0, 0, 0, 500, time.UTC), time.Date(2024, 1, 1, 0, 0, 1, 0, time.UTC)},
+		{"TZ=UTC */15 * * * *", time.Date(2024, 1, 1, 0, 50, 0, 0, time.UTC), time.Date(2024, 1, 1, 1, 0, 0, 0, time.UTC)},
+		{"TZ=UTC 5/20 * * * *", time.Date(2024, 1, 1, 0, 26, 0, 0, time.UTC), time.Date(2024, 1, 1, 0, 45, 0, 0, time.UTC)},
+		{"TZ=UTC 0 4 * * *", time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC)},
+		{"TZ=UTC 0 0 * * MON", time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), time.Date(2024, 1, 8, 0, 0, 0, 0, time.UTC)},
+		{"TZ=UTC 0 0 * * 7", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)},
+		{"TZ=UTC 0 0 31 * *", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC)},
+		{"TZ=UTC 0 0 29 2 *", time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		{"TZ=UTC 0 0 30 2 *", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}},
+		{"TZ=UTC @yearly", time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)},
+
+		// Either of the day of month and the day of week matches when both are restricted
+		{"TZ=UTC 0 0 13 * FRI", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+		{"TZ=UTC 0 0 13 * FRI", time.Date(2024, 1, 12, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 13, 0, 0, 0, 0, time.UTC)},
+		{"TZ=UTC 0 0 ? * FRI", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)},
+
+		// Time zones
+		{"CRON_TZ=Asia/Shanghai 0 4 * * *", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 4, 0, 0, 0, shanghai)},
+		{"CRON_TZ=Asia/Shanghai 0 4 * * *", time.Date(2024, 1, 1, 19, 0, 0, 0, time.UTC), time.Date(2024, 1, 2, 4, 0, 0, 0, shanghai)},
+		{"CRON_TZ=Asia/Shanghai 0 4 * * *", time.Date(2024, 1, 1, 21, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 4, 0, 0, 0, shanghai)},
+
+		// Daylight saving time, 02:30 does not exist on 10 Mar 2024 in New York
+		{"CRON_TZ=America/New_York 30 2 * * *", time.Date(2024, 3, 9, 12, 0, 0, 0, newYork), time.Date(2024, 3, 11, 2, 30, 0, 0, newYork)},
+		{"CRON_TZ=America/New_York 0 * * * *", time.Date(2024, 3, 10, 1, 30, 0, 0, newYork), time.Date(2024, 3, 10, 3, 0, 0, 0, newYork)},
+		{"CRON_TZ=America/New_York 0 4 * * *", time.Date(2024, 11, 3, 0, 0, 0, 0, newYork), time.Date(2024, 11, 3, 4, 0, 0, 0, newYork)},
+		// Midnight does not exist on 8 Sep 2024 in Santiago
+		{"CRON_TZ=America/Santiago 0 * 8 * *", time.Date(2024, 9, 7, 12, 0, 0, 0, time.UTC), time.Date(2024, 9, 8, 1, 0, 0, 0, santiago)},
+	}
+
+	for _, c := range cases {
+		s, err := ParseCron(c.expr)
+		if err != nil {
+			t.Fatalf("%q: %v", c.expr, err)
+		}
+		if next := s.Next(c.from); !next.Equal(c.next) {
+			t.Fatalf("%q from %v expect: %v, got: %v", c.expr, c.from, c.next, next)
+		}
+	}
+}
+
+func TestCronTimer(t *testing.T) {
+	s, err := ParseCron("TZ=UTC */10 * * * * *")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	start := time.Date(2024, 1, 1, 0, 0, 3, 0, time.UTC)
+	w := newTimerWheel(start)
+
+	var fired []time.Time
+	now := start
+	timer := &Timer{
+		fn:       func() { fired = append(fired, now) },
+		at:       s.Next(start).UnixNano(),
+		schedule: s,
+		counter:  infinite,
+	}
+	w.register(timer)
+
+	if next := timer.Next(); !next.Equal(start.Add(7 * time.Second)) {
+		t.Fatalf("next: %v", next)
+	}
+
+	for ; now.Before(start.Add(30 * time.Second)); now = now.Add(time.Second) {
+		w.cron(now)
+	}
+	if len(fired) != 3 {
+		t.Fatalf("fired: %v", fired)
+	}
+	for i, at := range fired {
+		if expect := start.Add(time.Duration(7+10*i) * time.Second); !at.Equal(expect) {
+			t.Fatalf("expect: %v, got: %v", expect, at)
+		}
+	}
+
+	// The activations missed are skipped
+	now = start.Add(time.Hour + 5*time.Second)
+	w.cron(now)
+	if len(fired) != 4 {
+		t.Fatalf("fired: %v", fired)
+	}
+	if next := timer.Next(); !next.Equal(start.Add(time.Hour + 7*time.Second)) {
+		t.Fatalf("next: %v", next)
+	}
+
+	timer.Stop()
+	if next := timer.Next(); !next.IsZero() || w.timers != 0 {
+		t.Fatalf("next: %v, timers: %d", next, w.timers)
+	}
+}
+
+func TestNewCronTimer(t *testing.T) {
+	exists := liveTimers()
+
+	timer, err := NewCronTimer("* * * * * *", func() {})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if next := timer.Next(); next.Before(time.Now()) || next.After(time.Now().Add(time.Second)) {
+		t.Fatalf("next: %v", next)
+	}
+	timer.Stop()
+
+	if _, err := NewCronTimer("* * * *", func() {}); err == nil {
+		t.Fatal("expect error")
+	}
+	if _, err := NewCronTimer("0 0 30 2 *", func() {}); err == nil {
+		t.Fatal("expect error")
+	}
+	if n := liveTimers(); n != exists {
+		t.Fatalf("timers: %d", n)
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package scheduler
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"strconv"
+)
+
+type currentKey struct{}
+
+// WithCurrent returns a copy of ctx which tells that it is used by a task of s,
+// e.g. the context passed to a handler running on s
+func WithCurrent(ctx context.Context, s LocalScheduler) context.Context {
+	return context.WithValue(ctx, currentKey{}, s)
+}
+
+// IsCurrent reports whether ctx is used by a task of s, where waiting for
+// another task of s never returns. Only the contexts derived from the one
+// returned by WithCurrent are known, IsCurrent is false for the others.
+func IsCurrent(ctx context.Context, s LocalScheduler) bool {
+	current, ok := ctx.Value(currentKey{}).(LocalScheduler)
+	return ok && current == s
+}
+
+// goid returns the id of the current goroutine, which is parsed from the header
+// of the stack trace, e.g. "goroutine 18 [running]:". It is only used to tell
+// whether Close is called by the scheduler itself.
+func goid() int64 {
+	var buf [64]byte
+	n := runtime.Stack(buf[:], false)
+	fields := bytes.Fields(buf[:n])
+	if len(fields) < 2 {
+		return 0
+	}
+	id, _ := strconv.ParseInt(string(fields[1]), 10, 64)
+	return id
+}
@@ -23,6 +23,7 @@ package scheduler
 import (
 	"fmt"
 	"runtime/debug"
+	"sync"
 	"sync/atomic"
 	"time"
 
@@ -33,6 +34,7 @@ import (
 const (
 	messageQueueBacklog = 1 << 10
 	sessionCloseBacklog = 1 << 8
+	taskQueueBacklog    = 1 << 8
 )
 
 // LocalScheduler schedules task to a customized goroutine
@@ -44,14 +46,63 @@ type Task func()
 
 type Hook func()
 
+// Scheduler runs the tasks and the timers in order on its own goroutine. The
+// package level functions work on the default scheduler, and the components
+// can be bound to the named schedulers, see Named.
+type Scheduler struct {
+	name   string
+	tasks  chan Task
+	timers *timerWheel
+
+	mu   sync.Mutex
+	die  chan struct{} // nil if the scheduler is not running
+	exit chan struct{}
+	gid  int64 // id of the goroutine running the scheduler
+
+	refMu sync.Mutex
+	refs  int // number of the users sharing the scheduler, see Acquire
+}
+
 var (
-	chDie   = make(chan struct{})
-	chExit  = make(chan struct{})
-	chTasks = make(chan Task, 1<<8)
-	started int32
-	closed  int32
+	defaultScheduler = NewScheduler("default")
+
+	namedMu    sync.Mutex
+	schedulers = map[string]*Scheduler{}
 )
 
+// NewScheduler returns a new scheduler which is not registered as a named
+// scheduler, call Start or Sched to run it.
+func NewScheduler(name string) *Scheduler {
+	return &Scheduler{
+		name:   name,
+		tasks:  make(chan Task, taskQueueBacklog),
+		timers: newTimerWheel(time.Now()),
+	}
+}
+
+// Default returns the default scheduler
+func Default() *Scheduler {
+	return defaultScheduler
+}
+
+// Named returns the scheduler registered with name, which is created if not
+// exists. The default scheduler is returned if name is empty.
+func Named(name string) *Scheduler {
+	if name == "" {
+		return defaultScheduler
+	}
+
+	namedMu.Lock()
+	defer namedMu.Unlock()
+
+	s, found := schedulers[name]
+	if !found {
+		s = NewScheduler(name)
+		schedulers[name] = s
+	}
+	return s
+}
+
 func try(f func()) {
 	defer func() {
 		if err := recover(); err != nil {
@@ -61,40 +112,148 @@ func try(f func()) {
 	f()
 }
 
-func Sched() {
-	if atomic.AddInt32(&started, 1) != 1 {
-		return
+// Name returns the name of the scheduler
+func (s *Scheduler) Name() string {
+	return s.name
+}
+
+// Sched runs the scheduler on the current goroutine until it is closed, and
+// returns immediately if it is running already.
+func (s *Scheduler) Sched() {
+	if die, exit := s.prepare(); die != nil {
+		s.run(die, exit)
+	}
+}
+
+// Start runs the scheduler on a new goroutine, and does nothing if it is
+// running already.
+func (s *Scheduler) Start() {
+	if die, exit := s.prepare(); die != nil {
+		go s.run(die, exit)
 	}
+}
 
+func (s *Scheduler) prepare() (die, exit chan struct{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.die != nil {
+		return nil, nil
+	}
+	s.die, s.exit = make(chan struct{}), make(chan struct{})
+	return s.die, s.exit
+}
+
+func (s *Scheduler) run(die, exit chan struct{}) {
 	ticker := time.NewTicker(env.TimerPrecision)
+	atomic.StoreInt64(&s.gid, goid())
 	defer func() {
+		atomic.StoreInt64(&s.gid, 0)
 		ticker.Stop()
-		close(chExit)
+		close(exit)
 	}()
 
 	for {
 		select {
 		case <-ticker.C:
-			cron()
+			s.cron()
 
-		case f := <-chTasks:
+		case f := <-s.tasks:
 			try(f)
 
-		case <-chDie:
+		case <-die:
 			return
 		}
 	}
 }
 
-func Close() {
-	if atomic.AddInt32(&closed, 1) != 1 {
+func (s *Scheduler) cron() {
+	s.timers.cron(time.Now())
+}
+
+// Close stops the scheduler and waits for the running task, the tasks pushed
+// and the timers are kept for the scheduler started again. Close called from a
+// task or a timer of the scheduler returns without waiting, and the scheduler
+// stops once the task returns.
+func (s *Scheduler) Close() {
+	s.mu.Lock()
+	if s.die == nil {
+		s.mu.Unlock()
+		return
+	}
+	close(s.die)
+	exit := s.exit
+	s.die, s.exit = nil, nil
+	s.mu.Unlock()
+
+	if !s.isCurrent() {
+		<-exit
+	}
+	log.Println(fmt.Sprintf("Scheduler %s stopped", s.name))
+}
+
+// Acquire starts the scheduler if no other user acquired it, e.g. the nodes
+// sharing the scheduler in a process. Each Acquire must be paired with Release.
+func (s *Scheduler) Acquire() {
+	s.refMu.Lock()
+	defer s.refMu.Unlock()
+	if s.refs == 0 {
+		s.Start()
+	}
+	s.refs++
+}
+
+// Release closes the scheduler once all users acquired it released it
+func (s *Scheduler) Release() {
+	s.refMu.Lock()
+	defer s.refMu.Unlock()
+	if s.refs == 0 {
 		return
 	}
-	close(chDie)
-	<-chExit
-	log.Println("Scheduler stopped")
+	if s.refs--; s.refs == 0 {
+		s.Close()
+	}
+}
+
+// isCurrent reports whether the caller runs on the goroutine of the scheduler,
+// e.g. in a task or a timer of it
+func (s *Scheduler) isCurrent() bool {
+	gid := atomic.LoadInt64(&s.gid)
+	return gid != 0 && gid == goid()
 }
 
+// PushTask pushes the task to the scheduler
+func (s *Scheduler) PushTask(task Task) {
+	s.tasks <- task
+}
+
+// Schedule implements the LocalScheduler interface
+func (s *Scheduler) Schedule(task Task) {
+	s.PushTask(task)
+}
+
+// QueueDepth returns the number of tasks waiting to be scheduled
+func (s *Scheduler) QueueDepth() int {
+	return len(s.tasks)
+}
+
+// Sched runs the default scheduler on the current goroutine
+func Sched() {
+	defaultScheduler.Sched()
+}
+
+// Close stops the default scheduler
+func Close() {
+	defaultScheduler.Close()
+}
+
+// PushTask pushes the task to the default scheduler
 func PushTask(task Task) {
-	chTasks <- task
+	defaultScheduler.PushTask(task)
+}
+
+// QueueDepth returns the number of tasks waiting to be scheduled by the
+// default scheduler
+func QueueDepth() int {
+	return defaultScheduler.QueueDepth()
 }
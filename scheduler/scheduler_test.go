@@ -0,0 +1,137 @@
+package scheduler
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestScheduler(t *testing.T) {
+	s := NewScheduler("test")
+
+	// The tasks pushed before started are kept
+	done := make(chan string, 2)
+	s.PushTask(func() { done <- "task" })
+	s.NewAfterTimer(time.Millisecond, func() { done <- "timer" })
+
+	for i := 0; i < 3; i++ {
+		s.Start()
+		s.Start()
+		if i > 0 {
+			s.PushTask(func() { done <- "task" })
+			s.NewAfterTimer(time.Millisecond, func() { done <- "timer" })
+		}
+
+		received := map[string]bool{}
+		for len(received) < 2 {
+			select {
+			case v := <-done:
+				received[v] = true
+			case <-time.After(5 * time.Second):
+				t.Fatalf("round %d received: %v", i, received)
+			}
+		}
+
+		// Close is allowed to be called repeatedly
+		s.Close()
+		s.Close()
+	}
+}
+
+func TestSchedulerIndependent(t *testing.T) {
+	s1, s2 := NewScheduler("s1"), NewScheduler("s2")
+	s1.Start()
+	defer s1.Close()
+	s2.Start()
+	defer s2.Close()
+
+	blocked, release := make(chan struct{}), make(chan struct{})
+	s1.PushTask(func() {
+		close(blocked)
+		<-release
+	})
+	<-blocked
+
+	done := make(chan struct{})
+	s2.PushTask(func() { close(done) })
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("blocked by the other scheduler")
+	}
+	close(release)
+}
+
+func TestNamed(t *testing.T) {
+	if Named("") != Default() {
+		t.Fatal("expect the default scheduler")
+	}
+	s := Named("named")
+	if s.Name() != "named" || Named("named") != s {
+		t.Fatalf("named: %s", s.Name())
+	}
+	if Named("other") == s {
+		t.Fatal("expect another scheduler")
+	}
+}
+
+func TestSchedulerIsCurrent(t *testing.T) {
+	s := NewScheduler("current")
+
+	ctx := WithCurrent(context.Background(), s)
+	if IsCurrent(context.Background(), s) || IsCurrent(ctx, NewScheduler("other")) {
+		t.Fatal("expect the context is not used by the scheduler")
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	if !IsCurrent(ctx, s) {
+		t.Fatal("expect the derived context is used by the scheduler")
+	}
+}
+
+func TestSchedulerCloseFromTask(t *testing.T) {
+	s := NewScheduler("close")
+
+	// Close returns without waiting for the task calling it
+	closed := make(chan struct{})
+	s.Start()
+	s.PushTask(func() {
+		s.Close()
+		close(closed)
+	})
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("close from the task deadlocked")
+	}
+
+	closed = make(chan struct{})
+	s.Start()
+	s.NewAfterTimer(time.Millisecond, func() {
+		s.Close()
+		close(closed)
+	})
+	select {
+	case <-closed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("close from the timer deadlocked")
+	}
+}
+
+func TestSchedulerAcquire(t *testing.T) {
+	s := NewScheduler("acquire")
+	s.Acquire()
+	s.Acquire()
+
+	// The scheduler keeps running until all users released it
+	s.Release()
+	done := make(chan struct{})
+	s.PushTask(func() { close(done) })
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expect the scheduler running")
+	}
+	s.Release()
+	s.Release()
+}
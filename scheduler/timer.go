@@ -23,7 +23,6 @@ package scheduler
 import (
 	"fmt"
 	"log"
-	"math"
 	"runtime/debug"
 	"sync"
 	"sync/atomic"
@@ -32,19 +31,18 @@ import (
 
 const (
 	infinite = -1
-)
 
-var (
-	// timerManager manager for all timers
-	timerManager = &struct {
-		incrementID int64            // auto increment id
-		timers      map[int64]*Timer // all timers
-
-		muClosingTimer sync.RWMutex
-		closingTimer   []int64
-		muCreatedTimer sync.RWMutex
-		createdTimer   []*Timer
-	}{}
+	// The timers are scheduled on a hierarchical timing wheel with the tick of
+	// a millisecond, the lowest level has 256 slots and each of the 4 higher
+	// levels has 64 slots, which covers about 49 days, and the timers expiring
+	// later are parked in the highest level until they are in range
+	tick        = time.Millisecond
+	wheelBits   = 8
+	levelBits   = 6
+	wheelLevels = 5
+	wheelMask   = 1<<wheelBits - 1
+	levelMask   = 1<<levelBits - 1
+	maxTimeout  = 1<<(wheelBits+levelBits*(wheelLevels-1)) - 1
 )
 
 type (
@@ -62,98 +60,284 @@ type (
 	Timer struct {
 		id        int64          // timer id
 		fn        TimerFunc      // function that execute
-		createAt  int64          // timer create time
+		at        int64          // time of the next execution in nanoseconds
 		interval  time.Duration  // execution interval
 		condition TimerCondition // condition to cron job execution
-		elapse    int64          // total elapse time
+		schedule  *CronSchedule  // cron expression to cron job execution
 		closed    int32          // is timer closed
 		counter   int            // counter
+
+		wheel      *timerWheel // wheel which the timer registered to
+		registered bool        // whether the timer is not freed
+		list       *timerList  // slot the timer linked in, nil if not scheduled
+		prev, next *Timer
+	}
+
+	// timerList is a doubly linked list of the timers in a slot of the wheel
+	timerList struct {
+		head  Timer
+		level int // level of the wheel the list belongs to
+	}
+
+	// timerWheel is a hierarchical timing wheel, the timers are inserted and
+	// canceled in O(1)
+	timerWheel struct {
+		mu          sync.Mutex
+		incrementID int64                    // auto increment id
+		base        int64                    // next tick to process
+		levels      [wheelLevels][]timerList // slots of the levels
+		counts      [wheelLevels]int         // number of the timers linked in the levels
+		due         timerList                // timers expired already
+		conds       map[int64]*Timer         // condition timers checked on every cron
+		timers      int                      // number of the timers not freed
 	}
 )
 
-func init() {
-	timerManager.timers = map[int64]*Timer{}
+func newTimerWheel(now time.Time) *timerWheel {
+	w := &timerWheel{
+		base:  toTick(now.UnixNano()),
+		conds: map[int64]*Timer{},
+	}
+	for level := range w.levels {
+		size := 1 << levelBits
+		if level == 0 {
+			size = 1 << wheelBits
+		}
+		w.levels[level] = make([]timerList, size)
+		for i := range w.levels[level] {
+			w.levels[level][i].init()
+			w.levels[level][i].level = level
+		}
+	}
+	w.due.init()
+	return w
 }
 
-// ID returns id of current timer
-func (t *Timer) ID() int64 {
-	return t.id
+// toTick returns the tick of the time in nanoseconds, which is rounded up
+func toTick(nano int64) int64 {
+	return (nano + int64(tick) - 1) / int64(tick)
 }
 
-// Stop turns off a timer. After Stop, fn will not be called forever
-func (t *Timer) Stop() {
-	if atomic.AddInt32(&t.closed, 1) != 1 {
-		return
-	}
+func (l *timerList) init() {
+	l.head.prev, l.head.next = &l.head, &l.head
+}
 
-	t.counter = 0
+func (l *timerList) push(t *Timer) {
+	t.list = l
+	t.prev, t.next = l.head.prev, &l.head
+	l.head.prev.next = t
+	l.head.prev = t
 }
 
-// execute job function with protection
-func safecall(id int64, fn TimerFunc) {
-	defer func() {
-		if err := recover(); err != nil {
-			log.Println(fmt.Sprintf("Handle timer panic: %+v\n%s", err, debug.Stack()))
-		}
-	}()
+func (l *timerList) remove(t *Timer) {
+	t.prev.next = t.next
+	t.next.prev = t.prev
+	t.list, t.prev, t.next = nil, nil, nil
+}
 
-	fn()
+// take removes all timers from the list and appends them to timers
+func (l *timerList) take(timers []*Timer) []*Timer {
+	for t := l.head.next; t != &l.head; {
+		next := t.next
+		t.list, t.prev, t.next = nil, nil, nil
+		timers = append(timers, t)
+		t = next
+	}
+	l.init()
+	return timers
 }
 
-func cron() {
-	if len(timerManager.createdTimer) > 0 {
-		timerManager.muCreatedTimer.Lock()
-		for _, t := range timerManager.createdTimer {
-			timerManager.timers[t.id] = t
+// add links the timer in the slot of its tick, it must be called with the lock held
+func (w *timerWheel) add(t *Timer) {
+	expire := toTick(t.at)
+	idx := expire - w.base
+	switch {
+	case idx < 0:
+		// Expired already, which will be executed on the next cron
+		w.due.push(t)
+	case idx <= wheelMask:
+		w.levels[0][expire&wheelMask].push(t)
+	default:
+		if idx > maxTimeout {
+			expire = w.base + maxTimeout
+			idx = maxTimeout
+		}
+		level, shift := 1, uint(wheelBits)
+		for level < wheelLevels-1 && idx >= 1<<(shift+levelBits) {
+			level++
+			shift += levelBits
 		}
-		timerManager.createdTimer = timerManager.createdTimer[:0]
-		timerManager.muCreatedTimer.Unlock()
+		w.levels[level][(expire>>shift)&levelMask].push(t)
 	}
+	w.counts[t.list.level]++
+}
 
-	if len(timerManager.timers) < 1 {
-		return
+// free removes the timer from the wheel, it must be called with the lock held
+func (w *timerWheel) free(t *Timer) {
+	if t.list != nil {
+		w.counts[t.list.level]--
+		t.list.remove(t)
+	}
+	if t.condition != nil {
+		delete(w.conds, t.id)
+	}
+	if t.registered {
+		t.registered = false
+		w.timers--
 	}
+}
 
-	now := time.Now()
-	unn := now.UnixNano()
-	for id, t := range timerManager.timers {
-		if t.counter == infinite || t.counter > 0 {
-			// condition timer
-			if t.condition != nil {
-				if t.condition.Check(now) {
-					safecall(id, t.fn)
-				}
-				continue
+// advance processes the ticks until now and returns the expired timers, it must
+// be called with the lock held
+func (w *timerWheel) advance(now int64) []*Timer {
+	expired := w.due.take(nil)
+	w.counts[0] -= len(expired)
+	for w.base <= now {
+		if w.counts[0] == 0 && w.base&wheelMask != 0 {
+			// Skip to the next tick cascading the lowest level which is not empty
+			level, shift := 1, uint(wheelBits)
+			for level < wheelLevels && w.counts[level] == 0 {
+				level++
+				shift += levelBits
 			}
+			if level == wheelLevels {
+				w.base = now + 1
+				break
+			}
+			if w.base = ((w.base-1)>>shift + 1) << shift; w.base > now {
+				w.base = now + 1
+				break
+			}
+		}
 
-			// execute job
-			if t.createAt+t.elapse <= unn {
-				safecall(id, t.fn)
-				t.elapse += int64(t.interval)
-
-				// update timer counter
-				if t.counter != infinite && t.counter > 0 {
-					t.counter--
+		index := w.base & wheelMask
+		if index == 0 {
+			// Cascade the timers of the higher levels to the lower levels
+			for level, shift := 1, uint(wheelBits); level < wheelLevels; level, shift = level+1, shift+levelBits {
+				i := (w.base >> shift) & levelMask
+				timers := w.levels[level][i].take(nil)
+				w.counts[level] -= len(timers)
+				for _, t := range timers {
+					w.add(t)
+				}
+				if i != 0 {
+					break
 				}
 			}
 		}
+		w.base++
+		n := len(expired)
+		expired = w.levels[0][index].take(expired)
+		w.counts[0] -= len(expired) - n
+	}
+	return expired
+}
 
-		if t.counter == 0 {
-			timerManager.muClosingTimer.Lock()
-			timerManager.closingTimer = append(timerManager.closingTimer, t.id)
-			timerManager.muClosingTimer.Unlock()
-			continue
+// register registers the timer to the wheel
+func (w *timerWheel) register(t *Timer) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	t.id = atomic.AddInt64(&w.incrementID, 1)
+	t.wheel = w
+	t.registered = true
+	w.timers++
+	if t.condition != nil {
+		w.conds[t.id] = t
+	} else {
+		w.add(t)
+	}
+}
+
+// cron executes the timers expired and the condition timers satisfied, every
+// timer is executed once at most
+func (w *timerWheel) cron(now time.Time) {
+	var conds []*Timer
+	w.mu.Lock()
+	// Only the ticks elapsed entirely are processed, so that no timer fires
+	// before its time, which is rounded up to the tick
+	expired := w.advance(now.UnixNano() / int64(tick))
+	for _, t := range w.conds {
+		conds = append(conds, t)
+	}
+	w.mu.Unlock()
+
+	for _, t := range conds {
+		if !t.stopped() && t.condition.Check(now) {
+			safecall(t.id, t.fn)
 		}
 	}
 
-	if len(timerManager.closingTimer) > 0 {
-		timerManager.muClosingTimer.Lock()
-		for _, id := range timerManager.closingTimer {
-			delete(timerManager.timers, id)
+	for _, t := range expired {
+		if !t.stopped() {
+			safecall(t.id, t.fn)
+		}
+
+		w.mu.Lock()
+		if t.counter > 0 {
+			t.counter--
 		}
-		timerManager.closingTimer = timerManager.closingTimer[:0]
-		timerManager.muClosingTimer.Unlock()
+		switch {
+		case t.counter == 0 || t.stopped():
+			w.free(t)
+		case t.schedule != nil:
+			// The activations missed are skipped
+			if next := t.schedule.Next(now); next.IsZero() {
+				w.free(t)
+			} else {
+				t.at = next.UnixNano()
+				w.add(t)
+			}
+		default:
+			t.at += int64(t.interval)
+			w.add(t)
+		}
+		w.mu.Unlock()
+	}
+}
+
+// ID returns id of current timer
+func (t *Timer) ID() int64 {
+	return t.id
+}
+
+// Next returns the time of the next execution, or the zero time if the timer
+// is a condition timer or not going to be executed any more
+func (t *Timer) Next() time.Time {
+	t.wheel.mu.Lock()
+	defer t.wheel.mu.Unlock()
+
+	if !t.registered || t.condition != nil {
+		return time.Time{}
 	}
+	return time.Unix(0, t.at)
+}
+
+// Stop turns off a timer. After Stop, fn will not be called forever, and the
+// timer is freed right away
+func (t *Timer) Stop() {
+	if atomic.AddInt32(&t.closed, 1) != 1 {
+		return
+	}
+
+	t.wheel.mu.Lock()
+	t.wheel.free(t)
+	t.wheel.mu.Unlock()
+}
+
+func (t *Timer) stopped() bool {
+	return atomic.LoadInt32(&t.closed) > 0
+}
+
+// execute job function with protection
+func safecall(id int64, fn TimerFunc) {
+	defer func() {
+		if err := recover(); err != nil {
+			log.Println(fmt.Sprintf("Handle timer panic: %+v\n%s", err, debug.Stack()))
+		}
+	}()
+
+	fn()
 }
 
 // NewTimer returns a new Timer containing a function that will be called
@@ -162,7 +346,7 @@ func cron() {
 // The duration d must be greater than zero; if not, NewTimer will panic.
 // Stop the timer to release associated resources.
 func NewTimer(interval time.Duration, fn TimerFunc) *Timer {
-	return NewCountTimer(interval, infinite, fn)
+	return defaultScheduler.NewTimer(interval, fn)
 }
 
 // NewCountTimer returns a new Timer containing a function that will be called
@@ -171,6 +355,43 @@ func NewTimer(interval time.Duration, fn TimerFunc) *Timer {
 // The duration d must be greater than zero; if not, NewCountTimer will panic.
 // Stop the timer to release associated resources.
 func NewCountTimer(interval time.Duration, count int, fn TimerFunc) *Timer {
+	return defaultScheduler.NewCountTimer(interval, count, fn)
+}
+
+// NewAfterTimer returns a new Timer containing a function that will be called
+// after duration that specified by the duration argument.
+// The duration d must be greater than zero; if not, NewAfterTimer will panic.
+// Stop the timer to release associated resources.
+func NewAfterTimer(duration time.Duration, fn TimerFunc) *Timer {
+	return defaultScheduler.NewAfterTimer(duration, fn)
+}
+
+// NewCondTimer returns a new Timer containing a function that will be called
+// when condition satisfied that specified by the condition argument.
+// The duration d must be greater than zero; if not, NewCondTimer will panic.
+// Stop the timer to release associated resources.
+func NewCondTimer(condition TimerCondition, fn TimerFunc) *Timer {
+	return defaultScheduler.NewCondTimer(condition, fn)
+}
+
+// NewCronTimer returns a new Timer containing a function that will be called
+// at the activations of the cron expression, see ParseCron for the syntax of
+// the expression. The timer is stopped automatically if the expression can not
+// be satisfied any more.
+// Stop the timer to release associated resources.
+func NewCronTimer(expr string, fn TimerFunc) (*Timer, error) {
+	return defaultScheduler.NewCronTimer(expr, fn)
+}
+
+// NewTimer is like the package level NewTimer, but the function is called on
+// the scheduler s
+func (s *Scheduler) NewTimer(interval time.Duration, fn TimerFunc) *Timer {
+	return s.NewCountTimer(interval, infinite, fn)
+}
+
+// NewCountTimer is like the package level NewCountTimer, but the function is
+// called on the scheduler s
+func (s *Scheduler) NewCountTimer(interval time.Duration, count int, fn TimerFunc) *Timer {
 	if fn == nil {
 		panic("nano/timer: nil timer function")
 	}
@@ -179,39 +400,62 @@ func NewCountTimer(interval time.Duration, count int, fn TimerFunc) *Timer {
 	}
 
 	t := &Timer{
-		id:       atomic.AddInt64(&timerManager.incrementID, 1),
 		fn:       fn,
-		createAt: time.Now().UnixNano(),
+		at:       time.Now().UnixNano() + int64(interval), // first execution will be after interval
 		interval: interval,
-		elapse:   int64(interval), // first execution will be after interval
 		counter:  count,
 	}
-
-	timerManager.muCreatedTimer.Lock()
-	timerManager.createdTimer = append(timerManager.createdTimer, t)
-	timerManager.muCreatedTimer.Unlock()
+	s.timers.register(t)
 	return t
 }
 
-// NewAfterTimer returns a new Timer containing a function that will be called
-// after duration that specified by the duration argument.
-// The duration d must be greater than zero; if not, NewAfterTimer will panic.
-// Stop the timer to release associated resources.
-func NewAfterTimer(duration time.Duration, fn TimerFunc) *Timer {
-	return NewCountTimer(duration, 1, fn)
+// NewAfterTimer is like the package level NewAfterTimer, but the function is
+// called on the scheduler s
+func (s *Scheduler) NewAfterTimer(duration time.Duration, fn TimerFunc) *Timer {
+	return s.NewCountTimer(duration, 1, fn)
 }
 
-// NewCondTimer returns a new Timer containing a function that will be called
-// when condition satisfied that specified by the condition argument.
-// The duration d must be greater than zero; if not, NewCondTimer will panic.
-// Stop the timer to release associated resources.
-func NewCondTimer(condition TimerCondition, fn TimerFunc) *Timer {
+// NewCondTimer is like the package level NewCondTimer, but the function is
+// called on the scheduler s
+func (s *Scheduler) NewCondTimer(condition TimerCondition, fn TimerFunc) *Timer {
 	if condition == nil {
 		panic("nano/timer: nil condition")
 	}
+	if fn == nil {
+		panic("nano/timer: nil timer function")
+	}
 
-	t := NewCountTimer(time.Duration(math.MaxInt64), infinite, fn)
-	t.condition = condition
-
+	t := &Timer{
+		fn:        fn,
+		condition: condition,
+		counter:   infinite,
+	}
+	s.timers.register(t)
 	return t
 }
+
+// NewCronTimer is like the package level NewCronTimer, but the function is
+// called on the scheduler s
+func (s *Scheduler) NewCronTimer(expr string, fn TimerFunc) (*Timer, error) {
+	if fn == nil {
+		panic("nano/timer: nil timer function")
+	}
+
+	schedule, err := ParseCron(expr)
+	if err != nil {
+		return nil, err
+	}
+	next := schedule.Next(time.Now())
+	if next.IsZero() {
+		return nil, fmt.Errorf("nano/cron: %q is never satisfied", expr)
+	}
+
+	t := &Timer{
+		fn:       fn,
+		at:       next.UnixNano(),
+		schedule: schedule,
+		counter:  infinite,
+	}
+	s.timers.register(t)
+	return t, nil
+}
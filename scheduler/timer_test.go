@@ -26,16 +26,14 @@ import (
 	"time"
 )
 
+func liveTimers() int {
+	defaultScheduler.timers.mu.Lock()
+	defer defaultScheduler.timers.mu.Unlock()
+	return defaultScheduler.timers.timers
+}
+
 func TestNewTimer(t *testing.T) {
-	var exists = struct {
-		timers        int
-		createdTimes  int
-		closingTimers int
-	}{
-		timers:        len(timerManager.timers),
-		createdTimes:  len(timerManager.createdTimer),
-		closingTimers: len(timerManager.closingTimer),
-	}
+	exists := liveTimers()
 
 	const tc = 1000
 	var counter int64
@@ -46,35 +44,19 @@ func TestNewTimer(t *testing.T) {
 	}
 
 	<-time.After(5 * time.Millisecond)
-	cron()
-	cron()
+	defaultScheduler.cron()
+	defaultScheduler.cron()
 	if counter != tc*2 {
 		t.Fatalf("expect: %d, got: %d", tc*2, counter)
 	}
 
-	if len(timerManager.timers) != exists.timers+tc {
-		t.Fatalf("timers: %d", len(timerManager.timers))
-	}
-
-	if len(timerManager.createdTimer) != exists.createdTimes {
-		t.Fatalf("createdTimer: %d", len(timerManager.createdTimer))
-	}
-
-	if len(timerManager.closingTimer) != exists.closingTimers {
-		t.Fatalf("closingTimer: %d", len(timerManager.closingTimer))
+	if n := liveTimers(); n != exists+tc {
+		t.Fatalf("timers: %d", n)
 	}
 }
 
 func TestNewAfterTimer(t *testing.T) {
-	var exists = struct {
-		timers        int
-		createdTimes  int
-		closingTimers int
-	}{
-		timers:        len(timerManager.timers),
-		createdTimes:  len(timerManager.createdTimer),
-		closingTimers: len(timerManager.closingTimer),
-	}
+	exists := liveTimers()
 
 	const tc = 1000
 	var counter int64
@@ -85,20 +67,152 @@ func TestNewAfterTimer(t *testing.T) {
 	}
 
 	<-time.After(5 * time.Millisecond)
-	cron()
+	defaultScheduler.cron()
 	if counter != tc {
 		t.Fatalf("expect: %d, got: %d", tc, counter)
 	}
 
-	if len(timerManager.timers) != exists.timers {
-		t.Fatalf("timers: %d", len(timerManager.timers))
+	if n := liveTimers(); n != exists {
+		t.Fatalf("timers: %d", n)
+	}
+}
+
+func TestTimerStop(t *testing.T) {
+	exists := liveTimers()
+
+	const tc = 1000
+	var counter int64
+	timers := make([]*Timer, 0, tc)
+	for i := 0; i < tc; i++ {
+		timers = append(timers, NewTimer(time.Hour, func() {
+			atomic.AddInt64(&counter, 1)
+		}))
+	}
+	timers = append(timers, NewCondTimer(condFunc(func(time.Time) bool { return true }), func() {
+		atomic.AddInt64(&counter, 1)
+	}))
+
+	if n := liveTimers(); n != exists+tc+1 {
+		t.Fatalf("timers: %d", n)
+	}
+
+	// Stopped timers are freed without waiting for the next cron
+	for _, timer := range timers {
+		timer.Stop()
+		timer.Stop()
+	}
+	if n := liveTimers(); n != exists {
+		t.Fatalf("timers: %d", n)
+	}
+
+	defaultScheduler.cron()
+	if counter != 0 {
+		t.Fatalf("expect: 0, got: %d", counter)
+	}
+}
+
+type condFunc func(now time.Time) bool
+
+func (f condFunc) Check(now time.Time) bool { return f(now) }
+
+func TestTimerWheel(t *testing.T) {
+	start := time.Unix(1000, 0)
+	w := newTimerWheel(start)
+
+	// Timers on every level of the wheel and beyond the maximum timeout
+	delays := []time.Duration{
+		0,
+		time.Millisecond,
+		255 * time.Millisecond,
+		256 * time.Millisecond,
+		time.Second,
+		time.Minute,
+		time.Hour,
+		24 * time.Hour,
+		60 * 24 * time.Hour,
 	}
 
-	if len(timerManager.createdTimer) != exists.createdTimes {
-		t.Fatalf("createdTimer: %d", len(timerManager.createdTimer))
+	var fired []time.Duration
+	for i := len(delays) - 1; i >= 0; i-- {
+		delay := delays[i]
+		w.register(&Timer{
+			fn:      func() { fired = append(fired, delay) },
+			at:      start.Add(delay).UnixNano(),
+			counter: 1,
+		})
+	}
+
+	var last time.Duration
+	for i, delay := range delays {
+		// Nothing fires before its tick
+		if delay > last+tick {
+			w.cron(start.Add(delay - tick))
+			if len(fired) != i {
+				t.Fatalf("%v fired: %v", delay-tick, fired)
+			}
+		}
+		w.cron(start.Add(delay))
+		if len(fired) != i+1 {
+			t.Fatalf("%v fired: %v", delay, fired)
+		}
+		last = delay
 	}
 
-	if len(timerManager.closingTimer) != exists.closingTimers {
-		t.Fatalf("closingTimer: %d", len(timerManager.closingTimer))
+	for i, delay := range delays {
+		if fired[i] != delay {
+			t.Fatalf("expect: %v, got: %v", delays, fired)
+		}
+	}
+	if w.timers != 0 || w.counts != [wheelLevels]int{} {
+		t.Fatalf("timers: %d, counts: %v", w.timers, w.counts)
+	}
+}
+
+func TestTimerWheelInterval(t *testing.T) {
+	start := time.Unix(1000, 0)
+	w := newTimerWheel(start)
+
+	var counter int
+	timer := &Timer{
+		fn:       func() { counter++ },
+		at:       start.Add(300 * time.Millisecond).UnixNano(),
+		interval: 300 * time.Millisecond,
+		counter:  3,
+	}
+	w.register(timer)
+
+	for ms := 1; ms <= 1000; ms++ {
+		w.cron(start.Add(time.Duration(ms) * time.Millisecond))
+		if expect := ms / 300; expect <= 3 && counter != expect {
+			t.Fatalf("%dms expect: %d, got: %d", ms, expect, counter)
+		}
+	}
+	if counter != 3 || w.timers != 0 {
+		t.Fatalf("counter: %d, timers: %d", counter, w.timers)
+	}
+}
+
+func TestTimerWheelEarly(t *testing.T) {
+	start := time.Unix(1000, 0)
+	w := newTimerWheel(start)
+
+	var fired bool
+	at := start.Add(1500 * time.Microsecond)
+	w.register(&Timer{
+		fn:      func() { fired = true },
+		at:      at.UnixNano(),
+		counter: 1,
+	})
+
+	// The timer never fires before its time within the tick
+	for _, now := range []time.Time{start.Add(1200 * time.Microsecond), at.Add(-time.Nanosecond)} {
+		w.cron(now)
+		if fired {
+			t.Fatalf("fired at %v before %v", now.Sub(start), at.Sub(start))
+		}
+	}
+	w.cron(start.Add(2 * time.Millisecond))
+	if !fired {
+		t.Fatalf("not fired at the tick following its time")
 	}
 }
@@ -37,7 +37,15 @@ func ResetNodeId(nodeId uint64) {
 	Connections = newDefaultConnectionServer(nodeId)
 }
 
-// Connections is a global variable which is used by session.
+// NewConnection returns a connection service which generates the session ids
+// with nodeId, e.g. the connection service of a node
+func NewConnection(nodeId uint64) Connection {
+	return newDefaultConnectionServer(nodeId)
+}
+
+// Connections is a global variable which is used by session. It generates the
+// session ids of the nodes without NodeID, and counts the client connections of
+// all nodes in the process.
 //var Connections  = newConnectionService()
 var Connections Connection = newDefaultConnectionServer(uint64(os.Getpid()))
 
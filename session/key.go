@@ -0,0 +1,309 @@
+// Copyright (c) nano Authors. All Rights Reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package session
+
+import (
+	"bytes"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// Errors that could be occurred during accessing the typed keys.
+var (
+	ErrKeyNotFound  = errors.New("key not found")
+	ErrKeyMismatch  = errors.New("value type does not match the key")
+	ErrKeyDuplicate = errors.New("replicated key registered twice")
+)
+
+// Codec encodes and decodes the values of a replicated key
+type Codec[T any] interface {
+	Marshal(v T) ([]byte, error)
+	Unmarshal(data []byte) (T, error)
+}
+
+// GobCodec is the default Codec of the replicated keys
+type GobCodec[T any] struct{}
+
+// Marshal implements the Codec interface
+func (GobCodec[T]) Marshal(v T) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := gob.NewEncoder(buf).Encode(v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Unmarshal implements the Codec interface
+func (GobCodec[T]) Unmarshal(data []byte) (T, error) {
+	var v T
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(&v)
+	return v, err
+}
+
+// Key is a typed key of the session data, the value is stored under the name of
+// the key, so that it is also accessible via the untyped accessors, e.g. Value.
+type Key[T any] struct {
+	name  string
+	codec Codec[T] // nil if the values are not replicated
+}
+
+// replicatedKey is the untyped view of a replicated key
+type replicatedKey interface {
+	marshal(v interface{}) ([]byte, error)
+	unmarshal(data []byte) (interface{}, error)
+}
+
+var (
+	muReplicated   sync.RWMutex
+	replicatedKeys = map[string]replicatedKey{}
+)
+
+// NewKey returns a typed key of the name
+func NewKey[T any](name string) *Key[T] {
+	return &Key[T]{name: name}
+}
+
+// NewReplicatedKey returns a typed key of the name whose values travel with the
+// messages forwarded to the backend nodes, the values are encoded via codec or
+// GobCodec if codec is nil. Every process of the cluster should declare the key,
+// e.g. as a package level variable, and the name must be unique.
+func NewReplicatedKey[T any](name string, codec Codec[T]) *Key[T] {
+	if codec == nil {
+		codec = GobCodec[T]{}
+	}
+	k := &Key[T]{name: name, codec: codec}
+
+	muReplicated.Lock()
+	defer muReplicated.Unlock()
+	if _, found := replicatedKeys[name]; found {
+		panic(fmt.Sprintf("session: %v: %s", ErrKeyDuplicate, name))
+	}
+	replicatedKeys[name] = k
+	return k
+}
+
+// valueKey is a replicated untyped key, see Replicate
+type valueKey struct{}
+
+func (valueKey) marshal(v interface{}) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	if err := gob.NewEncoder(buf).Encode(&v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (valueKey) unmarshal(data []byte) (interface{}, error) {
+	var v interface{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&v); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// Replicate marks the untyped keys as replicated, so that the values set via Set
+// travel with the messages forwarded to the backend nodes. The values are encoded
+// via gob, the types other than the basic types must be registered via gob.Register.
+// The keys already replicated are ignored.
+func Replicate(names ...string) {
+	muReplicated.Lock()
+	defer muReplicated.Unlock()
+	for _, name := range names {
+		if _, found := replicatedKeys[name]; !found {
+			replicatedKeys[name] = valueKey{}
+		}
+	}
+}
+
+// Name returns the name of the key
+func (k *Key[T]) Name() string {
+	return k.name
+}
+
+// Replicated returns whether the values of the key are replicated
+func (k *Key[T]) Replicated() bool {
+	return k.codec != nil
+}
+
+// Get returns the value associated with the key, ErrKeyNotFound is returned if
+// absent, and ErrKeyMismatch if the value is not a T.
+func (k *Key[T]) Get(s *Session) (T, error) {
+	s.RLock()
+	v, found := s.data[k.name]
+	s.RUnlock()
+
+	var value T
+	if !found {
+		return value, ErrKeyNotFound
+	}
+	value, ok := v.(T)
+	if !ok {
+		return value, ErrKeyMismatch
+	}
+	return value, nil
+}
+
+// Set associates the value with the key, the value of a replicated key is encoded
+// once set, so set it again after mutating it, and it is not stored if it fails to
+// be encoded. The value encoded as nil data is stored but not replicated.
+func (k *Key[T]) Set(s *Session, value T) error {
+	var data []byte
+	if k.codec != nil {
+		var err error
+		if data, err = k.codec.Marshal(value); err != nil {
+			return err
+		}
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	s.data[k.name] = value
+	delete(s.forwarded, k.name)
+	if data != nil {
+		s.replicated[k.name] = data
+	} else {
+		delete(s.replicated, k.name)
+	}
+	return nil
+}
+
+// Delete deletes the value associated with the key
+func (k *Key[T]) Delete(s *Session) {
+	s.Remove(k.name)
+}
+
+func (k *Key[T]) marshal(v interface{}) ([]byte, error) {
+	value, ok := v.(T)
+	if !ok {
+		return nil, ErrKeyMismatch
+	}
+	return k.codec.Marshal(value)
+}
+
+func (k *Key[T]) unmarshal(data []byte) (interface{}, error) {
+	return k.codec.Unmarshal(data)
+}
+
+// lookupReplicated returns the replicated key of the name, or nil if absent
+func lookupReplicated(name string) replicatedKey {
+	muReplicated.RLock()
+	defer muReplicated.RUnlock()
+	return replicatedKeys[name]
+}
+
+// Replicate marks the untyped keys as replicated for current session only, e.g.
+// the keys forwarded by the node of the session, see the package level Replicate
+func (s *Session) Replicate(names ...string) {
+	s.Lock()
+	defer s.Unlock()
+	if s.replicates == nil {
+		s.replicates = make(map[string]bool, len(names))
+	}
+	for _, name := range names {
+		s.replicates[name] = true
+	}
+}
+
+// lookupReplicated returns the replicated key of the name for current session,
+// or nil if absent, it must be called with the lock held
+func (s *Session) lookupReplicated(name string) replicatedKey {
+	if key := lookupReplicated(name); key != nil {
+		return key
+	}
+	if s.replicates[name] {
+		return valueKey{}
+	}
+	return nil
+}
+
+// ReplicatedValues returns the encoded values of the replicated keys, which travel
+// with the messages forwarded to the backend nodes
+func (s *Session) ReplicatedValues() map[string][]byte {
+	s.RLock()
+	defer s.RUnlock()
+
+	if len(s.replicated) == 0 {
+		return nil
+	}
+	values := make(map[string][]byte, len(s.replicated))
+	for name, data := range s.replicated {
+		values[name] = data
+	}
+	return values
+}
+
+// SetReplicatedValues stores the encoded values of the replicated keys forwarded
+// from the gate, which are merged into the session: the keys forwarded last time
+// but absent from values are deleted on the gate, so are deleted, and the values
+// set on the backend are kept until the gate forwards the keys, whose values win
+// since the values set on the backend are not propagated back to the gate. The
+// values of the keys not declared in current process are ignored.
+func (s *Session) SetReplicatedValues(values map[string][]byte) error {
+	s.Lock()
+	defer s.Unlock()
+
+	decoded := make(map[string]interface{}, len(values))
+	for name, data := range values {
+		key := s.lookupReplicated(name)
+		if key == nil {
+			continue
+		}
+		value, err := key.unmarshal(data)
+		if err != nil {
+			return fmt.Errorf("session: decode replicated key %s: %v", name, err)
+		}
+		decoded[name] = value
+	}
+	for name := range s.forwarded {
+		if _, found := decoded[name]; !found {
+			delete(s.data, name)
+			delete(s.replicated, name)
+		}
+	}
+	s.forwarded = make(map[string]bool, len(decoded))
+	for name, value := range decoded {
+		s.data[name] = value
+		s.replicated[name] = values[name]
+		s.forwarded[name] = true
+	}
+	return nil
+}
+
+// store associates the value with the key and encodes the value if the key is
+// replicated, it must be called with the lock held. The value is stored even if
+// it fails to be encoded, but it will not be replicated.
+func (s *Session) store(key string, value interface{}) error {
+	s.data[key] = value
+	delete(s.replicated, key)
+	delete(s.forwarded, key)
+	if r := s.lookupReplicated(key); r != nil {
+		data, err := r.marshal(value)
+		if err != nil {
+			return fmt.Errorf("session: encode replicated key %s: %v", key, err)
+		}
+		if data != nil {
+			s.replicated[key] = data
+		}
+	}
+	return nil
+}
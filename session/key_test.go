@@ -0,0 +1,216 @@
+package session
+
+import (
+	"errors"
+	"testing"
+)
+
+// The replicated keys are registered globally, so they are declared once for
+// the tests run repeatedly
+var (
+	roomKey    = NewReplicatedKey[int64]("test.room", nil)
+	scoreKey   = NewReplicatedKey[int]("test.score", nil)
+	levelKey   = NewKey[int]("test.level")
+	failingKey = NewReplicatedKey[string]("test.failing", failingCodec{})
+	emptyKey   = NewReplicatedKey[string]("test.empty", emptyCodec{})
+)
+
+type failingCodec struct{}
+
+func (failingCodec) Marshal(string) ([]byte, error)   { return nil, errors.New("marshal") }
+func (failingCodec) Unmarshal([]byte) (string, error) { return "", errors.New("unmarshal") }
+
+// emptyCodec encodes the empty strings as nil data
+type emptyCodec struct{}
+
+func (emptyCodec) Marshal(v string) ([]byte, error) {
+	if v == "" {
+		return nil, nil
+	}
+	return []byte(v), nil
+}
+func (emptyCodec) Unmarshal(data []byte) (string, error) { return string(data), nil }
+
+func TestKey(t *testing.T) {
+	s := New(nil)
+	if _, err := levelKey.Get(s); err != ErrKeyNotFound {
+		t.Fatalf("expect ErrKeyNotFound, got %v", err)
+	}
+	if err := levelKey.Set(s, 3); err != nil {
+		t.Fatal(err)
+	}
+	if level, err := levelKey.Get(s); err != nil || level != 3 {
+		t.Fatalf("expect 3, got %v %v", level, err)
+	}
+	if s.Int("test.level") != 3 {
+		t.Fatalf("expect the value accessible via the untyped accessors")
+	}
+
+	s.Set("test.level", "3")
+	if _, err := levelKey.Get(s); err != ErrKeyMismatch {
+		t.Fatalf("expect ErrKeyMismatch, got %v", err)
+	}
+	levelKey.Delete(s)
+	if s.HasKey("test.level") {
+		t.Fatalf("expect the key deleted")
+	}
+
+	if err := failingKey.Set(s, "value"); err == nil || s.HasKey("test.failing") {
+		t.Fatalf("expect the value failed to be encoded not stored")
+	}
+
+	// The value encoded as nil data replaces the replicated one
+	if err := emptyKey.Set(s, "value"); err != nil {
+		t.Fatal(err)
+	}
+	if err := emptyKey.Set(s, ""); err != nil {
+		t.Fatal(err)
+	}
+	if values := s.ReplicatedValues(); values != nil {
+		t.Fatalf("expect the stale value not replicated, got %v", values)
+	}
+}
+
+func TestReplicatedKey(t *testing.T) {
+	gate := New(nil)
+	if gate.ReplicatedValues() != nil {
+		t.Fatalf("expect no replicated values")
+	}
+	if err := roomKey.Set(gate, 1001); err != nil {
+		t.Fatal(err)
+	}
+	levelKey.Set(gate, 3)
+
+	values := gate.ReplicatedValues()
+	if len(values) != 1 {
+		t.Fatalf("expect only the replicated key, got %v", values)
+	}
+	backend := New(nil)
+	if err := backend.SetReplicatedValues(values); err != nil {
+		t.Fatal(err)
+	}
+	if room, err := roomKey.Get(backend); err != nil || room != 1001 {
+		t.Fatalf("expect 1001, got %v %v", room, err)
+	}
+	if backend.HasKey("test.level") {
+		t.Fatalf("expect the unreplicated key not forwarded")
+	}
+
+	// The values set via the untyped accessors or restored are replicated too
+	gate.Set("test.room", int64(1002))
+	backend.SetReplicatedValues(gate.ReplicatedValues())
+	if room, _ := roomKey.Get(backend); room != 1002 {
+		t.Fatalf("expect 1002, got %v", room)
+	}
+	gate.Restore(map[string]interface{}{"test.room": int64(1003)})
+	backend.SetReplicatedValues(gate.ReplicatedValues())
+	if room, _ := roomKey.Get(backend); room != 1003 {
+		t.Fatalf("expect 1003, got %v", room)
+	}
+
+	// The replicated values set on the backend are kept until the gate
+	// forwards the keys
+	if err := scoreKey.Set(backend, 10); err != nil {
+		t.Fatal(err)
+	}
+	if err := backend.SetReplicatedValues(gate.ReplicatedValues()); err != nil {
+		t.Fatal(err)
+	}
+	if score, err := scoreKey.Get(backend); err != nil || score != 10 {
+		t.Fatalf("expect 10, got %v %v", score, err)
+	}
+
+	// The value failed to be encoded is stored but not replicated
+	gate.Set("test.room", "mismatched")
+	if gate.String("test.room") != "mismatched" || gate.ReplicatedValues() != nil {
+		t.Fatalf("expect the value stored but not replicated")
+	}
+
+	// The replicated values deleted on the gate are deleted on the backend
+	roomKey.Delete(gate)
+	if gate.ReplicatedValues() != nil {
+		t.Fatalf("expect the replicated value deleted")
+	}
+	if err := backend.SetReplicatedValues(gate.ReplicatedValues()); err != nil {
+		t.Fatal(err)
+	}
+	if backend.HasKey("test.room") {
+		t.Fatalf("expect the replicated value deleted on the backend")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expect the duplicated key panics")
+		}
+	}()
+	NewReplicatedKey[int64]("test.room", nil)
+}
+
+type bindEntity struct {
+	NetworkEntity
+	uid int64
+	err error
+}
+
+func (e *bindEntity) Bind(uid int64) error {
+	if e.err != nil {
+		return e.err
+	}
+	e.uid = uid
+	return nil
+}
+
+func TestReplicate(t *testing.T) {
+	Replicate("test.nickname", "test.room")
+
+	gate := New(nil)
+	gate.Set("test.nickname", "bob")
+	backend := New(nil)
+	if err := backend.SetReplicatedValues(gate.ReplicatedValues()); err != nil {
+		t.Fatal(err)
+	}
+	if backend.String("test.nickname") != "bob" {
+		t.Fatalf("expect bob, got %v", backend.Value("test.nickname"))
+	}
+
+	// The keys replicated by a session are not replicated by the others
+	gate.Replicate("test.title")
+	gate.Set("test.title", "knight")
+	other := New(nil)
+	other.Set("test.title", "knight")
+	if other.ReplicatedValues() != nil {
+		t.Fatalf("expect the key not replicated by the other session")
+	}
+	if err := backend.SetReplicatedValues(gate.ReplicatedValues()); err != nil {
+		t.Fatal(err)
+	}
+	if backend.HasKey("test.title") {
+		t.Fatalf("expect the key not replicated by the backend ignored")
+	}
+	backend.Replicate("test.title")
+	if err := backend.SetReplicatedValues(gate.ReplicatedValues()); err != nil {
+		t.Fatal(err)
+	}
+	if backend.String("test.title") != "knight" {
+		t.Fatalf("expect knight, got %v", backend.Value("test.title"))
+	}
+
+	// The UID bound is propagated by the network entity
+	entity := &bindEntity{}
+	s := New(entity)
+	if err := s.Bind(7); err != nil {
+		t.Fatal(err)
+	}
+	if entity.uid != 7 {
+		t.Fatalf("expect the UID propagated, got %d", entity.uid)
+	}
+
+	// The UID failed to be propagated is not bound
+	entity.err = errors.New("unavailable")
+	if err := s.Bind(8); err != entity.err {
+		t.Fatalf("expect the propagation error, got %v", err)
+	}
+	if s.UID() != 7 || entity.uid != 7 {
+		t.Fatalf("expect the UID unchanged, got %d %d", s.UID(), entity.uid)
+	}
+}
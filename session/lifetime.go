@@ -6,21 +6,30 @@ type (
 	// session low-level connection broken.
 	LifetimeHandler func(*Session)
 
-	lifetime struct {
+	// LifetimeManager manages the callbacks of the session lifetime
+	LifetimeManager struct {
 		// callbacks that emitted on session closed
 		onClosed []LifetimeHandler
 	}
 )
 
-var Lifetime = &lifetime{}
+// Lifetime manages the callbacks for the sessions of the nodes which are not
+// bound to their own lifetime manager, see NewLifetime
+var Lifetime = NewLifetime()
+
+// NewLifetime returns a new lifetime manager, which can be bound to a node to
+// manage the callbacks for its sessions only
+func NewLifetime() *LifetimeManager {
+	return &LifetimeManager{}
+}
 
 // OnClosed set the Callback which will be called
 // when session is closed Waring: session has closed.
-func (lt *lifetime) OnClosed(h LifetimeHandler) {
+func (lt *LifetimeManager) OnClosed(h LifetimeHandler) {
 	lt.onClosed = append(lt.onClosed, h)
 }
 
-func (lt *lifetime) Close(s *Session) {
+func (lt *LifetimeManager) Close(s *Session) {
 	if len(lt.onClosed) < 1 {
 		return
 	}
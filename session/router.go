@@ -43,6 +43,28 @@ func (r *Router) Delete(service string) {
 	r.routes.Delete(service)
 }
 
+// Unbind deletes the binds to the address, e.g. the remote service is draining
+func (r *Router) Unbind(address string) {
+	r.routes.Range(func(service, addr interface{}) bool {
+		if addr.(string) == address {
+			r.routes.Delete(service)
+		}
+		return true
+	})
+}
+
+// Services returns the services bound to the address
+func (r *Router) Services(address string) []string {
+	var services []string
+	r.routes.Range(func(service, addr interface{}) bool {
+		if addr.(string) == address {
+			services = append(services, service.(string))
+		}
+		return true
+	})
+	return services
+}
+
 // Find finds the address corresponding a remote service
 func (r *Router) Find(service string) (string, bool) {
 	v, found := r.routes.Load(service)
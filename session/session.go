@@ -21,12 +21,14 @@
 package session
 
 import (
+	"context"
 	"errors"
 	"net"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/lonng/nano/internal/log"
 	"github.com/lonng/nano/service"
 )
 
@@ -41,11 +43,32 @@ type NetworkEntity interface {
 	RemoteAddr() net.Addr
 }
 
+// Caller is implemented by the network entities which could send a request to a
+// handler and wait for the response, see Session.Call
+type Caller interface {
+	Call(ctx context.Context, route string, v, reply interface{}) error
+}
+
+// Kicker is implemented by the network entities which could send a kick packet
+// to the client before closing, see Session.Kick
+type Kicker interface {
+	Kick(reason KickReason) error
+}
+
 var (
 	//ErrIllegalUID represents a invalid uid
 	ErrIllegalUID = errors.New("illegal uid")
+	// ErrCallNotSupported represents the network entity does not implement Caller
+	ErrCallNotSupported = errors.New("network entity does not support call")
 )
 
+// KickReason represents the reason why the session was kicked, which will be
+// sent to the client in the kick packet
+type KickReason struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
 // Session represents a client session which could storage temp data during low-level
 // keep connected, all data will be released when the low-level connection was broken.
 // Session instance related to the client will be passed to Handler method as the first
@@ -55,26 +78,49 @@ type Session struct {
 	id           int64                  // session global unique id
 	uid          int64                  // binding user id
 	lastTime     int64                  // last heartbeat time
-	entity       NetworkEntity          // low-level network entity
+	entity       atomic.Value           // low-level network entity, replaced on resumption
 	data         map[string]interface{} // session data store
+	replicated   map[string][]byte      // encoded values of the replicated keys
+	replicates   map[string]bool        // untyped keys replicated by current session
+	forwarded    map[string]bool        // replicated keys last forwarded from the gate
 	router       *Router
 }
 
 // New returns a new session instance
 // a NetworkEntity is a low-level network instance
 func New(entity NetworkEntity) *Session {
-	return &Session{
-		id:       service.Connections.SessionID(),
-		entity:   entity,
-		data:     make(map[string]interface{}),
-		lastTime: time.Now().Unix(),
-		router:   newRouter(),
+	return NewWithID(service.Connections.SessionID(), entity)
+}
+
+// NewWithID returns a new session instance with the specified id, e.g. the id
+// generated by the connection service of a node
+func NewWithID(id int64, entity NetworkEntity) *Session {
+	s := &Session{
+		id:         id,
+		data:       make(map[string]interface{}),
+		replicated: make(map[string][]byte),
+		lastTime:   time.Now().Unix(),
+		router:     newRouter(),
 	}
+	s.entity.Store(entityHolder{entity})
+	return s
+}
+
+// entityHolder holds the network entity in the atomic value, which requires the
+// values stored are of the same concrete type
+type entityHolder struct {
+	entity NetworkEntity
 }
 
 // NetworkEntity returns the low-level network agent object
 func (s *Session) NetworkEntity() NetworkEntity {
-	return s.entity
+	return s.entity.Load().(entityHolder).entity
+}
+
+// SetNetworkEntity replaces the low-level network agent object, e.g. the session
+// resumed on a new connection
+func (s *Session) SetNetworkEntity(entity NetworkEntity) {
+	s.entity.Store(entityHolder{entity})
 }
 
 // NetworkEntity returns the service router
@@ -84,23 +130,36 @@ func (s *Session) Router() *Router {
 
 // RPC sends message to remote server
 func (s *Session) RPC(route string, v interface{}) error {
-	return s.entity.RPC(route, v)
+	return s.NetworkEntity().RPC(route, v)
+}
+
+// Call sends a request to the handler of route and waits for the response, which
+// will be unmarshaled into reply. Call blocks until the response arrives or ctx
+// is done. A context-aware handler should pass its context, so that calling a
+// handler on the scheduler running the caller, which never responds, fails
+// immediately instead of waiting until ctx is done.
+func (s *Session) Call(ctx context.Context, route string, v, reply interface{}) error {
+	caller, ok := s.NetworkEntity().(Caller)
+	if !ok {
+		return ErrCallNotSupported
+	}
+	return caller.Call(ctx, route, v, reply)
 }
 
 // Push message to client
 func (s *Session) Push(route string, v interface{}) error {
-	return s.entity.Push(route, v)
+	return s.NetworkEntity().Push(route, v)
 }
 
 // Response message to client
 func (s *Session) Response(v interface{}) error {
-	return s.entity.Response(v)
+	return s.NetworkEntity().Response(v)
 }
 
 // ResponseMID responses message to client, mid is
 // request message ID
 func (s *Session) ResponseMID(mid uint64, v interface{}) error {
-	return s.entity.ResponseMid(mid, v)
+	return s.NetworkEntity().ResponseMid(mid, v)
 }
 
 // ID returns the session id
@@ -115,7 +174,13 @@ func (s *Session) UID() int64 {
 
 // LastMid returns the last message id
 func (s *Session) LastMid() uint64 {
-	return s.entity.LastMid()
+	return s.NetworkEntity().LastMid()
+}
+
+// binder is implemented by the network entities which propagate the UID bound
+// to the session, e.g. the sessions forwarded from a gate
+type binder interface {
+	Bind(uid int64) error
 }
 
 // Bind bind UID to current session
@@ -124,19 +189,37 @@ func (s *Session) Bind(uid int64) error {
 		return ErrIllegalUID
 	}
 
+	// The UID is bound only if it is propagated by the network entity
+	if b, ok := s.NetworkEntity().(binder); ok {
+		if err := b.Bind(uid); err != nil {
+			return err
+		}
+	}
 	atomic.StoreInt64(&s.uid, uid)
 	return nil
 }
 
+// Kick sends a kick packet with the reason to the client after the pending messages
+// flushed, and then closes the session. The session is closed without the kick
+// packet if the network entity does not implement Kicker.
+func (s *Session) Kick(reason KickReason) error {
+	entity := s.NetworkEntity()
+	kicker, ok := entity.(Kicker)
+	if !ok {
+		return entity.Close()
+	}
+	return kicker.Kick(reason)
+}
+
 // Close terminate current session, session related data will not be released,
 // all related data should be Clear explicitly in Session closed callback
 func (s *Session) Close() {
-	s.entity.Close()
+	s.NetworkEntity().Close()
 }
 
 // RemoteAddr returns the remote network address.
 func (s *Session) RemoteAddr() net.Addr {
-	return s.entity.RemoteAddr()
+	return s.NetworkEntity().RemoteAddr()
 }
 
 // Remove delete data associated with the key from session storage
@@ -145,14 +228,19 @@ func (s *Session) Remove(key string) {
 	defer s.Unlock()
 
 	delete(s.data, key)
+	delete(s.replicated, key)
+	delete(s.forwarded, key)
 }
 
-// Set associates value with the key in session storage
+// Set associates value with the key in session storage, the value of a replicated
+// key which fails to be encoded is not replicated
 func (s *Session) Set(key string, value interface{}) {
 	s.Lock()
 	defer s.Unlock()
 
-	s.data[key] = value
+	if err := s.store(key, value); err != nil {
+		log.Println(err)
+	}
 }
 
 // HasKey decides whether a key has associated value
@@ -407,6 +495,13 @@ func (s *Session) Restore(data map[string]interface{}) {
 	defer s.Unlock()
 
 	s.data = data
+	s.replicated = make(map[string][]byte)
+	s.forwarded = nil
+	for key, value := range data {
+		if err := s.store(key, value); err != nil {
+			log.Println(err)
+		}
+	}
 }
 
 // Clear releases all data related to current session
@@ -416,4 +511,6 @@ func (s *Session) Clear() {
 
 	s.uid = 0
 	s.data = map[string]interface{}{}
+	s.replicated = map[string][]byte{}
+	s.forwarded = nil
 }
@@ -1,6 +1,21 @@
 package session
 
-import "testing"
+import (
+	"context"
+	"net"
+	"testing"
+)
+
+// plainEntity implements the NetworkEntity interface only
+type plainEntity struct{ closed bool }
+
+func (*plainEntity) Push(string, interface{}) error        { return nil }
+func (*plainEntity) RPC(string, interface{}) error         { return nil }
+func (*plainEntity) LastMid() uint64                       { return 0 }
+func (*plainEntity) Response(interface{}) error            { return nil }
+func (*plainEntity) ResponseMid(uint64, interface{}) error { return nil }
+func (e *plainEntity) Close() error                        { e.closed = true; return nil }
+func (*plainEntity) RemoteAddr() net.Addr                  { return nil }
 
 func TestNewSession(t *testing.T) {
 	s := New(nil)
@@ -173,3 +188,40 @@ func TestSession_Restore(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestSession_CallNotSupported(t *testing.T) {
+	s := New(&plainEntity{})
+	if err := s.Call(context.Background(), "Service.Method", nil, nil); err != ErrCallNotSupported {
+		t.Fatalf("expect %v, got %v", ErrCallNotSupported, err)
+	}
+}
+
+func TestSession_KickNotSupported(t *testing.T) {
+	entity := &plainEntity{}
+	s := New(entity)
+	if err := s.Kick(KickReason{Code: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if !entity.closed {
+		t.Fatal("session is not closed")
+	}
+}
+
+func TestSession_SetNetworkEntity(t *testing.T) {
+	s := New(&plainEntity{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			s.Push("route", nil)
+		}
+	}()
+	entity := &plainEntity{}
+	for i := 0; i < 100; i++ {
+		s.SetNetworkEntity(entity)
+	}
+	<-done
+	if s.NetworkEntity() != entity {
+		t.Fatal("network entity is not replaced")
+	}
+}
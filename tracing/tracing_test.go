@@ -0,0 +1,95 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestTraceparent(t *testing.T) {
+	const s = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+	sc, err := ParseTraceparent(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sc.TraceID.String() != "4bf92f3577b34da6a3ce929d0e0e4736" || sc.SpanID.String() != "00f067aa0ba902b7" || !sc.Sampled {
+		t.Fatalf("unexpected span context: %+v", sc)
+	}
+	if sc.Traceparent() != s {
+		t.Fatalf("got %s, want %s", sc.Traceparent(), s)
+	}
+
+	invalid := []string{
+		"",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-00",
+		"ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+		"00-4BF92F3577B34DA6A3CE929D0E0E4736-00f067aa0ba902b7-01",
+		"00-00000000000000000000000000000000-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e47-00f067aa0ba902b7-01",
+		"00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902bz-01",
+	}
+	for _, s := range invalid {
+		if _, err := ParseTraceparent(s); err != ErrInvalidTraceparent {
+			t.Fatalf("%q: got %v, want %v", s, err, ErrInvalidTraceparent)
+		}
+	}
+
+	// The future versions may append more fields
+	if _, err := ParseTraceparent("01" + s[2:] + "-extra"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestPropagation(t *testing.T) {
+	exporter := NewMemoryExporter()
+	tracer := NewTracer(exporter)
+
+	root := tracer.Start("root", SpanContext{})
+	ctx := ContextWithSpan(context.Background(), root)
+	if SpanFromContext(ctx) != root || SpanContextFromContext(ctx) != root.SpanContext() {
+		t.Fatal("span is not carried by the context")
+	}
+
+	metadata := Inject(nil, SpanContextFromContext(ctx))
+	remote := ContextWithRemoteSpanContext(context.Background(), Extract(metadata))
+	child := tracer.Start("child", SpanContextFromContext(remote))
+	child.SetAttribute("key", "value")
+	child.SetError(errors.New("failure"))
+	child.End()
+	root.End()
+	root.End()
+
+	spans := exporter.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	if spans[0].Context.TraceID != root.Context.TraceID || spans[0].ParentID != root.Context.SpanID {
+		t.Fatalf("child span is not linked to the root span: %+v", spans[0])
+	}
+	if spans[0].Attributes["key"] != "value" || spans[0].Err == nil {
+		t.Fatalf("unexpected child span: %+v", spans[0])
+	}
+
+	// All methods of a nil tracer and a nil span are no-op
+	var nilTracer *Tracer
+	span := nilTracer.Start("nil", SpanContext{})
+	span.SetAttribute("key", "value")
+	span.End()
+	if span.SpanContext().IsValid() || Inject(nil, span.SpanContext()) != nil {
+		t.Fatal("nil span should have an invalid span context")
+	}
+
+	buf := &bytes.Buffer{}
+	NewWriterExporter(buf).ExportSpan(spans[0])
+	v := map[string]interface{}{}
+	if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+		t.Fatal(err)
+	}
+	if v["parentId"] != root.Context.SpanID.String() {
+		t.Fatalf("unexpected exported span: %s", buf.String())
+	}
+}